@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/fyzanshaik/pokedex/internal/pokeapi"
+)
+
+const ABILITY_USAGE string = "Usage: ability <ability-name> [--lang <code>]"
+
+func commandAbility(ctx context.Context, c *pokeapi.Config, args ...string) error {
+	abilityName, language := "", pokeapi.DEFAULT_LANGUAGE
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; {
+		case arg == "--lang":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--lang needs a language code. %s", ABILITY_USAGE)
+			}
+			i++
+			language = args[i]
+		case strings.HasPrefix(arg, "--lang="):
+			language = strings.TrimPrefix(arg, "--lang=")
+		case strings.HasPrefix(arg, "--"):
+			return fmt.Errorf("unknown flag %s. %s", arg, ABILITY_USAGE)
+		case abilityName != "":
+			return fmt.Errorf("unexpected argument %q. %s", arg, ABILITY_USAGE)
+		default:
+			abilityName = arg
+		}
+	}
+	if abilityName == "" {
+		return fmt.Errorf("you must provide an ability name. %s", ABILITY_USAGE)
+	}
+
+	ability, err := c.Client.GetAbilityWithContext(ctx, abilityName)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		return fmt.Errorf("no ability named %s", abilityName)
+	}
+	if err != nil {
+		return fmt.Errorf("Error getting ability data: %w", err)
+	}
+
+	fmt.Printf("Name: %s\n", ability.LocalizedName(language))
+	if effect := ability.Effect(language); effect != "" {
+		fmt.Printf("Effect: %s\n", effect)
+	}
+
+	if len(ability.Pokemon) == 0 {
+		fmt.Println("No Pokemon can have this ability.")
+		return nil
+	}
+	fmt.Println("Pokemon with this ability:")
+	for _, holder := range ability.Pokemon {
+		if holder.IsHidden {
+			fmt.Printf("  - %s (hidden)\n", holder.Pokemon.Name)
+		} else {
+			fmt.Printf("  - %s\n", holder.Pokemon.Name)
+		}
+	}
+
+	return nil
+}
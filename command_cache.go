@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fyzanshaik/pokedex/internal/pokeapi"
+	"github.com/fyzanshaik/pokedex/internal/pokecache"
+)
+
+const CACHE_USAGE string = "Usage: cache <stats|keys|clear|evict <key>|export <file>|import <file>>"
+
+// scopedCache is the part of the cache the cache subcommands other than
+// stats work on: the namespace of the API in use, so e.g. clearing the cache
+// of a mirror keeps the entries of the public PokéAPI.
+type scopedCache interface {
+	pokecache.Store
+	Keys() []string
+	Clear() error
+	Export(w io.Writer) (int, error)
+	Import(r io.Reader) (int, error)
+}
+
+func commandCache(ctx context.Context, c *pokeapi.Config, args ...string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("you must provide a subcommand. %s", CACHE_USAGE)
+	}
+
+	subcommand := strings.ToLower(args[0])
+	if subcommand == "stats" {
+		printCacheStats(c)
+		return nil
+	}
+	scope, ok := c.Client.Cache().(scopedCache)
+	if !ok {
+		return fmt.Errorf("the cache does not support cache %s", subcommand)
+	}
+
+	switch subcommand {
+	case "keys":
+		keys := scope.Keys()
+		if len(keys) == 0 {
+			fmt.Println("The cache is empty.")
+			return nil
+		}
+		for _, key := range keys {
+			fmt.Printf(" - %s\n", key)
+		}
+	case "clear":
+		if err := scope.Clear(); err != nil {
+			return fmt.Errorf("Error clearing cache: %w", err)
+		}
+		fmt.Println("Cache cleared.")
+	case "evict":
+		if len(args) < 2 {
+			return fmt.Errorf("you must provide a key. Usage: cache evict <key>")
+		}
+		if scope.Delete(c.Client.CacheKey(args[1])) {
+			fmt.Printf("Evicted %s\n", args[1])
+		} else {
+			fmt.Printf("%s was not cached\n", args[1])
+		}
+	case "export":
+		if len(args) < 2 {
+			return fmt.Errorf("you must provide a file. Usage: cache export <file>")
+		}
+		return exportCache(scope, args[1])
+	case "import":
+		if len(args) < 2 {
+			return fmt.Errorf("you must provide a file. Usage: cache import <file>")
+		}
+		return importCache(scope, args[1])
+	default:
+		return fmt.Errorf("unknown cache subcommand %q. %s", args[0], CACHE_USAGE)
+	}
+
+	return nil
+}
+
+func exportCache(scope scopedCache, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("Error creating snapshot file: %w", err)
+	}
+
+	written, err := scope.Export(file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("Error exporting cache: %w", err)
+	}
+
+	fmt.Printf("Exported %d entries to %s\n", written, path)
+	return nil
+}
+
+func importCache(scope scopedCache, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("Error opening snapshot file: %w", err)
+	}
+	defer file.Close()
+
+	imported, err := scope.Import(file)
+	if err != nil {
+		return fmt.Errorf("Error importing cache: %w", err)
+	}
+
+	fmt.Printf("Imported %d entries from %s\n", imported, path)
+	return nil
+}
+
+// printCacheStats prints the counters of the whole cache, which is shared by
+// every API base URL.
+func printCacheStats(c *pokeapi.Config) {
+	stats := appCache.Stats()
+
+	hitRate := 0.0
+	if lookups := stats.Hits + stats.Misses; lookups > 0 {
+		hitRate = float64(stats.Hits) / float64(lookups) * 100
+	}
+
+	fmt.Println("Cache stats:")
+	fmt.Printf("  - entries: %d\n", stats.Entries)
+	fmt.Printf("  - size: %s (%s uncompressed)\n", formatBytes(stats.Bytes), formatBytes(stats.RawBytes))
+	fmt.Printf("  - decoded values: %s\n", formatBytes(stats.DecodedBytes))
+	fmt.Printf("  - hits: %d\n", stats.Hits)
+	fmt.Printf("  - misses: %d\n", stats.Misses)
+	fmt.Printf("  - hit rate: %.1f%%\n", hitRate)
+	fmt.Printf("  - evictions: %d\n", stats.Evictions)
+	fmt.Printf("  - expirations: %d\n", stats.Expirations)
+	fmt.Printf("  - oldest entry: %s\n", stats.OldestAge.Round(time.Second))
+}
+
+func formatBytes(n int) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := unit, 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
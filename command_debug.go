@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/fyzanshaik/pokedex/internal/pokeapi"
+	"github.com/fyzanshaik/pokedex/internal/pokecache"
+)
+
+const DEBUG_USAGE string = "Usage: debug <on|off>"
+
+// stopDebug unsubscribes the debug overlay while it is on.
+var stopDebug func()
+
+// debugOn is read by API callbacks running on other goroutines.
+var debugOn atomic.Bool
+
+func commandDebug(ctx context.Context, c *pokeapi.Config, args ...string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("you must say on or off. %s", DEBUG_USAGE)
+	}
+
+	switch args[0] {
+	case "on":
+		if stopDebug != nil {
+			fmt.Println("Debug overlay is already on.")
+			return nil
+		}
+		stopDebug = appCache.Subscribe(printCacheEvent)
+		debugOn.Store(true)
+		fmt.Println("Debug overlay on: cache events, requests and rate limit waits will be shown as they happen.")
+	case "off":
+		if stopDebug == nil {
+			fmt.Println("Debug overlay is already off.")
+			return nil
+		}
+		stopDebug()
+		stopDebug = nil
+		debugOn.Store(false)
+		fmt.Println("Debug overlay off.")
+	default:
+		return fmt.Errorf("unknown debug mode %q. %s", args[0], DEBUG_USAGE)
+	}
+	return nil
+}
+
+func printCacheEvent(e pokecache.Event) {
+	key := e.Key
+	if _, rest, found := strings.Cut(key, pokecache.NAMESPACE_SEPARATOR); found {
+		key = rest
+	}
+
+	if e.Size > 0 {
+		fmt.Printf("  [cache %s] %s (%s)\n", e.Kind, key, formatBytes(e.Size))
+		return
+	}
+	fmt.Printf("  [cache %s] %s\n", e.Kind, key)
+}
+
+// printRateLimitWait reports requests the client rate limiter held back while
+// the debug overlay is on.
+func printRateLimitWait(url string, wait time.Duration) {
+	if debugOn.Load() {
+		fmt.Printf("  [rate limit] waited %s before %s\n", wait.Round(time.Millisecond), url)
+	}
+}
+
+// logFetches reports every request that reaches the network, with its outcome
+// and how long it took including retries, while the debug overlay is on.
+func logFetches(next pokeapi.Handler) pokeapi.Handler {
+	return func(ctx context.Context, req pokeapi.Request) (pokeapi.Response, error) {
+		if !debugOn.Load() {
+			return next(ctx, req)
+		}
+
+		start := time.Now()
+		res, err := next(ctx, req)
+		took := time.Since(start).Round(time.Millisecond)
+		switch {
+		case err != nil:
+			fmt.Printf("  [fetch] %s failed after %s: %v\n", req.URL, took, err)
+		case res.NotModified:
+			fmt.Printf("  [fetch] %s not modified (%s)\n", req.URL, took)
+		default:
+			fmt.Printf("  [fetch] %s (%s, %s)\n", req.URL, formatBytes(len(res.Entry.Val)), took)
+		}
+		return res, err
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/fyzanshaik/pokedex/internal/pokeapi"
+)
+
+const EVOLUTION_USAGE string = "Usage: evolution <pokemon-name>"
+
+func commandEvolution(ctx context.Context, c *pokeapi.Config, args ...string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("you must provide a Pokemon name. %s", EVOLUTION_USAGE)
+	}
+
+	pokemonName := args[0]
+
+	species, err := speciesOf(ctx, c.Client, pokemonName)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		return fmt.Errorf("no Pokémon named %s", pokemonName)
+	}
+	if err != nil {
+		return fmt.Errorf("Error getting species data: %w", err)
+	}
+
+	chainID := species.EvolutionChainID()
+	if chainID == 0 {
+		fmt.Printf("%s does not evolve.\n", species.Name)
+		return nil
+	}
+	chain, err := c.Client.GetEvolutionChainWithContext(ctx, chainID)
+	if err != nil {
+		return fmt.Errorf("Error getting evolution chain: %w", err)
+	}
+
+	if len(chain.Chain.EvolvesTo) == 0 {
+		fmt.Printf("%s does not evolve.\n", species.Name)
+		return nil
+	}
+
+	fmt.Printf("Evolution chain of %s:\n", species.Name)
+	printChainLink(chain.Chain, "", "", species.Name)
+	return nil
+}
+
+// speciesOf returns the species of the Pokémon called name. Most species share
+// the name of their default Pokémon; for the others, such as wormadam-plant,
+// the species is found through the Pokémon.
+func speciesOf(ctx context.Context, client *pokeapi.Client, name string) (pokeapi.PokemonSpecies, error) {
+	species, err := client.GetPokemonSpeciesWithContext(ctx, name)
+	if !errors.Is(err, pokeapi.ErrNotFound) {
+		return species, err
+	}
+
+	pokemon, err := client.GetPokemonWithContext(ctx, name)
+	if err != nil {
+		return pokeapi.PokemonSpecies{}, err
+	}
+	return client.GetPokemonSpeciesWithContext(ctx, pokemon.Species.Name)
+}
+
+// printChainLink prints link and what it evolves to as a tree, marking the
+// species that was asked for. prefix is written before the link itself and
+// indent before its children.
+func printChainLink(link pokeapi.ChainLink, prefix, indent, asked string) {
+	line := prefix + link.Species.Name
+	if link.IsBaby {
+		line += " (baby)"
+	}
+	if how := describeEvolution(link.EvolutionDetails); how != "" {
+		line += ": " + how
+	}
+	if link.Species.Name == asked {
+		line += "  <"
+	}
+	fmt.Println("  " + line)
+
+	for i, next := range link.EvolvesTo {
+		if i == len(link.EvolvesTo)-1 {
+			printChainLink(next, indent+"└── ", indent+"    ", asked)
+		} else {
+			printChainLink(next, indent+"├── ", indent+"│   ", asked)
+		}
+	}
+}
+
+// describeEvolution joins the alternative ways of reaching a link, dropping
+// the duplicates PokéAPI lists for different games.
+func describeEvolution(details []pokeapi.EvolutionDetail) string {
+	var ways []string
+	for _, detail := range details {
+		if way := detail.Describe(); !slices.Contains(ways, way) {
+			ways = append(ways, way)
+		}
+	}
+	return strings.Join(ways, " or ")
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/fyzanshaik/pokedex/internal/pokeapi"
+)
+
+const MOVES_USAGE string = "Usage: moves <pokemon-name> [--version-group <name>] [--method <name>]"
+
+// movesOptions are the arguments of the moves command.
+type movesOptions struct {
+	pokemon      string
+	versionGroup string
+	method       string
+}
+
+func commandMoves(ctx context.Context, c *pokeapi.Config, args ...string) error {
+	opts, err := parseMovesArgs(args)
+	if err != nil {
+		return err
+	}
+
+	pokemon, err := c.Client.GetPokemonWithContext(ctx, opts.pokemon)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		return fmt.Errorf("no Pokémon named %s", opts.pokemon)
+	}
+	if err != nil {
+		return fmt.Errorf("Error getting Pokemon data: %w", err)
+	}
+
+	if opts.versionGroup == "" {
+		opts.versionGroup = pokemon.LatestVersionGroup()
+	}
+	learnset := pokemon.Learnset(opts.versionGroup, opts.method)
+	if len(learnset) == 0 {
+		fmt.Printf("%s learns no moves in %s%s.\n", pokemon.Name, opts.versionGroup, methodSuffix(opts.method))
+		return nil
+	}
+
+	names := make([]string, len(learnset))
+	for i, move := range learnset {
+		names[i] = move.Name
+	}
+	moves, err := c.Client.GetMovesWithContext(ctx, names)
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	fmt.Printf("Moves %s learns in %s%s:\n", pokemon.Name, opts.versionGroup, methodSuffix(opts.method))
+	printMoves(learnset, moves)
+
+	if err != nil {
+		fmt.Printf("Some moves are shown without their data:\n%s\n", describeError(err))
+	}
+	return nil
+}
+
+// parseMovesArgs reads the Pokémon name and the optional filters, given as
+// "--flag value" or "--flag=value".
+func parseMovesArgs(args []string) (movesOptions, error) {
+	var opts movesOptions
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "--") {
+			if opts.pokemon != "" {
+				return opts, fmt.Errorf("unexpected argument %q. %s", arg, MOVES_USAGE)
+			}
+			opts.pokemon = arg
+			continue
+		}
+
+		flag, value, found := strings.Cut(arg, "=")
+		if !found {
+			if i+1 >= len(args) {
+				return opts, fmt.Errorf("%s needs a value. %s", flag, MOVES_USAGE)
+			}
+			i++
+			value = args[i]
+		}
+		switch flag {
+		case "--version-group":
+			opts.versionGroup = value
+		case "--method":
+			opts.method = value
+		default:
+			return opts, fmt.Errorf("unknown flag %s. %s", flag, MOVES_USAGE)
+		}
+	}
+
+	if opts.pokemon == "" {
+		return opts, fmt.Errorf("you must provide a Pokemon name. %s", MOVES_USAGE)
+	}
+	return opts, nil
+}
+
+func methodSuffix(method string) string {
+	if method == "" {
+		return ""
+	}
+	return " by " + method
+}
+
+// printMoves prints the learnset as a table, enriched with the move data
+// fetched for it. Missing data is shown as "-".
+func printMoves(learnset []pokeapi.LearnableMove, moves []pokeapi.Move) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  LEVEL\tMOVE\tTYPE\tCLASS\tPOWER\tACC\tPP\tMETHOD\tEFFECT")
+	for i, learnable := range learnset {
+		move := moves[i]
+
+		level := "-"
+		if learnable.Method == pokeapi.LEARN_METHOD_LEVEL_UP {
+			level = strconv.Itoa(learnable.Level)
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			level,
+			learnable.Name,
+			orDash(move.Type.Name),
+			orDash(move.DamageClass.Name),
+			optionalInt(move.Power),
+			optionalInt(move.Accuracy),
+			optionalInt(move.PP),
+			learnable.Method,
+			orDash(move.Effect()),
+		)
+	}
+	w.Flush()
+}
+
+func optionalInt(n *int) string {
+	if n == nil {
+		return "-"
+	}
+	return strconv.Itoa(*n)
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
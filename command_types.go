@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fyzanshaik/pokedex/internal/pokeapi"
+)
+
+const WEAKNESS_USAGE string = "Usage: weakness <pokemon-name>"
+const MATCHUP_USAGE string = "Usage: matchup <attacking-type> <pokemon-name>"
+
+func commandWeakness(ctx context.Context, c *pokeapi.Config, args ...string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("you must provide a Pokemon name. %s", WEAKNESS_USAGE)
+	}
+
+	pokemon, err := getPokemonForMatchup(ctx, c, args[0])
+	if err != nil {
+		return err
+	}
+
+	defending := pokemon.TypeNames()
+	chart, err := c.Client.TypeChartWithContext(ctx, defending...)
+	if err != nil {
+		return fmt.Errorf("Error getting type data: %w", err)
+	}
+
+	// Group the attacking types by multiplier, strongest first
+	byMultiplier := make(map[float64][]string)
+	for attacking, multiplier := range chart.Matchups(defending...) {
+		byMultiplier[multiplier] = append(byMultiplier[multiplier], attacking)
+	}
+	multipliers := make([]float64, 0, len(byMultiplier))
+	for multiplier := range byMultiplier {
+		multipliers = append(multipliers, multiplier)
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(multipliers)))
+
+	fmt.Printf("%s (%s) takes:\n", pokemon.Name, strings.Join(defending, "/"))
+	if len(multipliers) == 0 {
+		fmt.Println("  1x from every type")
+		return nil
+	}
+	for _, multiplier := range multipliers {
+		attacking := byMultiplier[multiplier]
+		sort.Strings(attacking)
+		fmt.Printf("  %s from %s\n", formatMultiplier(multiplier), strings.Join(attacking, ", "))
+	}
+	fmt.Println("  1x from every other type")
+
+	return nil
+}
+
+func commandMatchup(ctx context.Context, c *pokeapi.Config, args ...string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("you must provide an attacking type and a Pokemon name. %s", MATCHUP_USAGE)
+	}
+
+	attacking := args[0]
+	chart, err := c.Client.TypeChartWithContext(ctx, attacking)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		return fmt.Errorf("no type named %s", attacking)
+	}
+	if err != nil {
+		return fmt.Errorf("Error getting type data: %w", err)
+	}
+
+	pokemon, err := getPokemonForMatchup(ctx, c, args[1])
+	if err != nil {
+		return err
+	}
+
+	defending := pokemon.TypeNames()
+	multiplier := chart.Multiplier(attacking, defending...)
+	fmt.Printf("%s against %s (%s): %s, %s\n",
+		attacking,
+		pokemon.Name,
+		strings.Join(defending, "/"),
+		formatMultiplier(multiplier),
+		describeEffectiveness(multiplier),
+	)
+
+	return nil
+}
+
+// getPokemonForMatchup prefers a caught Pokémon and looks up any other.
+func getPokemonForMatchup(ctx context.Context, c *pokeapi.Config, pokemonName string) (pokeapi.Pokemon, error) {
+	if pokemon, caught := c.CaughtPokemon[pokemonName]; caught {
+		return pokemon, nil
+	}
+
+	pokemon, err := c.Client.GetPokemonWithContext(ctx, pokemonName)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		return pokeapi.Pokemon{}, fmt.Errorf("no Pokémon named %s", pokemonName)
+	}
+	if err != nil {
+		return pokeapi.Pokemon{}, fmt.Errorf("Error getting Pokemon data: %w", err)
+	}
+	return pokemon, nil
+}
+
+func formatMultiplier(multiplier float64) string {
+	return strconv.FormatFloat(multiplier, 'f', -1, 64) + "x"
+}
+
+func describeEffectiveness(multiplier float64) string {
+	switch {
+	case multiplier == 0:
+		return "no effect"
+	case multiplier < 1:
+		return "not very effective"
+	case multiplier > 1:
+		return "super effective"
+	}
+	return "neutral"
+}
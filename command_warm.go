@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/fyzanshaik/pokedex/internal/pokeapi"
+)
+
+const WARM_USAGE string = "Usage: warm [workers]"
+
+func commandWarm(ctx context.Context, c *pokeapi.Config, args ...string) error {
+	workers := pokeapi.DEFAULT_WARM_WORKERS
+	if len(args) > 0 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 {
+			return fmt.Errorf("workers must be a positive number. %s", WARM_USAGE)
+		}
+		workers = n
+	}
+
+	fmt.Printf("Warming the cache with %d workers...\n", workers)
+
+	stage := ""
+	report, err := c.Client.WarmWithContext(ctx, workers, func(p pokeapi.WarmProgress) {
+		if p.Stage != stage {
+			if stage != "" {
+				fmt.Println()
+			}
+			stage = p.Stage
+		}
+		fmt.Printf("\r  - %s: %d/%d", p.Stage, p.Done, p.Total)
+	})
+	if stage != "" {
+		fmt.Println()
+	}
+
+	fmt.Printf("Warmed %d areas and %d Pokemon (%d already cached)\n", report.Areas, report.Pokemon, report.Cached)
+	if len(report.Failed) > 0 {
+		fmt.Printf("%d requests failed. Run warm again to retry them.\n", len(report.Failed))
+	}
+	if err != nil {
+		return fmt.Errorf("Error warming cache: %w. Run warm again to resume.", err)
+	}
+	return nil
+}
@@ -2,7 +2,6 @@ module github.com/fyzanshaik/pokedex
 
 go 1.25.1
 
-require (
-	github.com/chzyer/readline v1.5.1 // indirect
-	golang.org/x/sys v0.0.0-20220310020820-b874c991c1a5 // indirect
-)
+require github.com/chzyer/readline v1.5.1
+
+require golang.org/x/sys v0.0.0-20220310020820-b874c991c1a5 // indirect
@@ -0,0 +1,85 @@
+package pokeapi
+
+import (
+	"context"
+	"strings"
+)
+
+// DEFAULT_LANGUAGE is the language localized text falls back to.
+const DEFAULT_LANGUAGE string = "en"
+
+// Ability is a passive effect a Pokémon can have in battle or in the
+// overworld, along with every Pokémon that can have it.
+type Ability struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	IsMainSeries bool   `json:"is_main_series"`
+	Generation   Result `json:"generation"`
+	Names        []struct {
+		Name     string `json:"name"`
+		Language Result `json:"language"`
+	} `json:"names"`
+	EffectEntries []struct {
+		Effect      string `json:"effect"`
+		ShortEffect string `json:"short_effect"`
+		Language    Result `json:"language"`
+	} `json:"effect_entries"`
+	// FlavorTextEntries are listed from the oldest version group to the
+	// newest.
+	FlavorTextEntries []struct {
+		FlavorText   string `json:"flavor_text"`
+		Language     Result `json:"language"`
+		VersionGroup Result `json:"version_group"`
+	} `json:"flavor_text_entries"`
+	Pokemon []struct {
+		IsHidden bool   `json:"is_hidden"`
+		Slot     int    `json:"slot"`
+		Pokemon  Result `json:"pokemon"`
+	} `json:"pokemon"`
+}
+
+// LocalizedName returns the name of the ability in language, such as "fr" or
+// "ja-Hrkt" in any case, or its identifier if it has no name in that
+// language.
+func (a Ability) LocalizedName(language string) string {
+	for _, name := range a.Names {
+		if strings.EqualFold(name.Language.Name, language) {
+			return name.Name
+		}
+	}
+	return a.Name
+}
+
+// Effect returns the effect of the ability in language. Few languages have
+// effect text, so it falls back to the newest flavor text in language and
+// then to the effect in DEFAULT_LANGUAGE. It returns "" if there is none.
+func (a Ability) Effect(language string) string {
+	if effect := a.effect(language); effect != "" {
+		return effect
+	}
+	for i := len(a.FlavorTextEntries) - 1; i >= 0; i-- {
+		if entry := a.FlavorTextEntries[i]; strings.EqualFold(entry.Language.Name, language) {
+			return strings.Join(strings.Fields(entry.FlavorText), " ")
+		}
+	}
+	return a.effect(DEFAULT_LANGUAGE)
+}
+
+func (a Ability) effect(language string) string {
+	for _, entry := range a.EffectEntries {
+		if strings.EqualFold(entry.Language.Name, language) {
+			return strings.Join(strings.Fields(entry.Effect), " ")
+		}
+	}
+	return ""
+}
+
+// GET https://pokeapi.co/api/v2/ability/{name}/
+func (c *Client) GetAbility(abilityName string) (Ability, error) {
+	return c.GetAbilityWithContext(context.Background(), abilityName)
+}
+
+// GetAbilityWithContext is GetAbility, giving up once ctx is done.
+func (c *Client) GetAbilityWithContext(ctx context.Context, abilityName string) (Ability, error) {
+	return fetch[Ability](ctx, c, c.baseURL+"/ability/"+abilityName)
+}
@@ -0,0 +1,89 @@
+package pokeapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var mockAbilityResponse = `{
+	"id": 9,
+	"name": "static",
+	"is_main_series": true,
+	"names": [
+		{"name": "Statik", "language": {"name": "de"}},
+		{"name": "せいでんき", "language": {"name": "ja-Hrkt"}},
+		{"name": "Static", "language": {"name": "en"}}
+	],
+	"effect_entries": [
+		{"effect": "Whenever a move makes contact with this Pokémon,\nthe move's user has a 30% chance of being paralyzed.", "short_effect": "Has a 30% chance of paralyzing attacking Pokémon on contact.", "language": {"name": "en"}},
+		{"effect": "Wenn eine Attacke dieses Pokémon berührt, wird der Angreifer mit 30% Wahrscheinlichkeit paralysiert.", "short_effect": "Kann bei Berührung paralysieren.", "language": {"name": "de"}}
+	],
+	"flavor_text_entries": [
+		{"flavor_text": "Paralyse\nparfois au contact.", "language": {"name": "fr"}, "version_group": {"name": "ruby-sapphire"}},
+		{"flavor_text": "Le contact peut\nparalyser.", "language": {"name": "fr"}, "version_group": {"name": "sword-shield"}}
+	],
+	"pokemon": [
+		{"is_hidden": false, "slot": 1, "pokemon": {"name": "pikachu"}},
+		{"is_hidden": true, "slot": 3, "pokemon": {"name": "electrike"}}
+	]
+}`
+
+func TestGetAbility(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ability/static" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, mockAbilityResponse)
+	}))
+	defer server.Close()
+
+	client := NewClient(nil, WithBaseURL(server.URL))
+
+	ability, err := client.GetAbility("static")
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+		return
+	}
+	if len(ability.Pokemon) != 2 || ability.Pokemon[0].Pokemon.Name != "pikachu" || !ability.Pokemon[1].IsHidden {
+		t.Errorf("expected pikachu and a hidden electrike, got %+v", ability.Pokemon)
+	}
+}
+
+func TestAbilityLocalization(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, mockAbilityResponse)
+	}))
+	defer server.Close()
+
+	ability, err := NewClient(nil, WithBaseURL(server.URL)).GetAbility("static")
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+		return
+	}
+
+	names := map[string]string{
+		"de":      "Statik",
+		"ja-hrkt": "せいでんき",
+		"ko":      "static",
+	}
+	for language, want := range names {
+		if got := ability.LocalizedName(language); got != want {
+			t.Errorf("expected the %s name %q, got %q", language, want, got)
+		}
+	}
+
+	effects := map[string]string{
+		"en": "Whenever a move makes contact with this Pokémon, the move's user has a 30% chance of being paralyzed.",
+		"de": "Wenn eine Attacke dieses Pokémon berührt, wird der Angreifer mit 30% Wahrscheinlichkeit paralysiert.",
+		"fr": "Le contact peut paralyser.",
+		"ko": "Whenever a move makes contact with this Pokémon, the move's user has a 30% chance of being paralyzed.",
+	}
+	for language, want := range effects {
+		if got := ability.Effect(language); got != want {
+			t.Errorf("expected the %s effect %q, got %q", language, want, got)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package pokeapi
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/fyzanshaik/pokedex/internal/pokecache"
+)
+
+// DEFAULT_TIMEOUT bounds each request unless WithTimeout or WithHTTPClient
+// says otherwise.
+const DEFAULT_TIMEOUT time.Duration = 30 * time.Second
+
+// DEFAULT_USER_AGENT is sent with every request unless WithUserAgent is given.
+const DEFAULT_USER_AGENT string = "pokedex-cli"
+
+// Client fetches PokéAPI resources through a cache. A Client is safe for
+// concurrent use; concurrent requests for the same resource share a single
+// network request.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	userAgent  string
+	timeout    time.Duration
+	cache      pokecache.Store
+	retry      RetryPolicy
+	limiter    *rateLimiter
+	// observeWait is told about requests delayed by the rate limiter.
+	observeWait func(url string, wait time.Duration)
+	middleware  []Middleware
+	// handler is the pipeline built from the options in NewClient.
+	handler Handler
+	flights flightGroup
+	aliases aliasTable
+}
+
+type ClientOption func(*Client)
+
+// WithBaseURL points the client at another PokéAPI v2 deployment, such as a
+// local mirror, e.g. "http://localhost:8000/api/v2".
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) {
+		c.baseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithHTTPClient makes requests with hc instead of a client of our own.
+func WithHTTPClient(hc *http.Client) ClientOption {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithTimeout bounds each request, including reading the body. A zero
+// timeout keeps the timeout of the HTTP client.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// NewClient returns a client for the public PokéAPI that caches responses in
+// cache. A nil cache disables caching.
+func NewClient(cache pokecache.Store, opts ...ClientOption) *Client {
+	if cache == nil {
+		cache = pokecache.NopStore{}
+	}
+	client := &Client{
+		baseURL:    BASE_URL,
+		httpClient: &http.Client{Timeout: DEFAULT_TIMEOUT},
+		userAgent:  DEFAULT_USER_AGENT,
+		cache:      cache,
+		retry:      defaultRetryPolicy(),
+		limiter:    newRateLimiter(DEFAULT_RATE_LIMIT, DEFAULT_RATE_BURST),
+	}
+	for _, opt := range opts {
+		opt(client)
+	}
+	if client.timeout > 0 {
+		// Copy so a shared HTTP client passed in is left untouched
+		hc := *client.httpClient
+		hc.Timeout = client.timeout
+		client.httpClient = &hc
+	}
+	client.handler = client.pipeline()
+	return client
+}
+
+// BaseURL returns the PokéAPI base URL the client requests.
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// Cache returns the store the client caches responses in.
+func (c *Client) Cache() pokecache.Store {
+	return c.cache
+}
+
+// ListLocationAreas returns the page of location areas at pageURL, or the
+// first page if pageURL is empty.
+// GET https://pokeapi.co/api/v2/location-area/
+func (c *Client) ListLocationAreas(pageURL string) (LocationArea, error) {
+	return c.ListLocationAreasWithContext(context.Background(), pageURL)
+}
+
+// ListLocationAreasWithContext is ListLocationAreas, giving up once ctx is
+// done.
+func (c *Client) ListLocationAreasWithContext(ctx context.Context, pageURL string) (LocationArea, error) {
+	if pageURL == "" {
+		pageURL = c.baseURL + "/location-area"
+	}
+	return fetch[LocationArea](ctx, c, pageURL)
+}
+
+// GET https://pokeapi.co/api/v2/location-area/{name}/
+func (c *Client) GetLocationInformation(locationName string) (LocationInformation, error) {
+	return c.GetLocationInformationWithContext(context.Background(), locationName)
+}
+
+// GetLocationInformationWithContext is GetLocationInformation, giving up once
+// ctx is done.
+func (c *Client) GetLocationInformationWithContext(ctx context.Context, locationName string) (LocationInformation, error) {
+	return fetch[LocationInformation](ctx, c, c.baseURL+"/location-area/"+locationName)
+}
+
+// GET https://pokeapi.co/api/v2/pokemon/{name}/
+func (c *Client) GetPokemon(pokemonName string) (Pokemon, error) {
+	return c.GetPokemonWithContext(context.Background(), pokemonName)
+}
+
+// GetPokemonWithContext is GetPokemon, giving up once ctx is done.
+func (c *Client) GetPokemonWithContext(ctx context.Context, pokemonName string) (Pokemon, error) {
+	return fetch[Pokemon](ctx, c, c.baseURL+"/pokemon/"+pokemonName)
+}
@@ -0,0 +1,156 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fyzanshaik/pokedex/internal/pokecache"
+)
+
+func TestClientUsesBaseURLAndUserAgent(t *testing.T) {
+	var path, userAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		userAgent = r.Header.Get("User-Agent")
+		fmt.Fprint(w, `{"id":25,"name":"pikachu","base_experience":112}`)
+	}))
+	defer server.Close()
+
+	cache := pokecache.NewCache(5 * time.Second)
+	defer cache.Close()
+	client := NewClient(cache, WithBaseURL(server.URL+"/api/v2/"), WithUserAgent("workshop-mirror-test"))
+
+	pokemon, err := client.GetPokemon("pikachu")
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+		return
+	}
+	if pokemon.BaseExperience != 112 {
+		t.Errorf("expected base experience 112, got %d", pokemon.BaseExperience)
+		return
+	}
+	if path != "/api/v2/pokemon/pikachu" {
+		t.Errorf("expected request to the mirror path, got %s", path)
+		return
+	}
+	if userAgent != "workshop-mirror-test" {
+		t.Errorf("expected custom user agent, got %q", userAgent)
+		return
+	}
+	if client.BaseURL() != server.URL+"/api/v2" {
+		t.Errorf("expected trailing slash to be trimmed, got %s", client.BaseURL())
+		return
+	}
+}
+
+func TestClientListLocationAreasDefaultsToFirstPage(t *testing.T) {
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		fmt.Fprint(w, mockLocationAreaResponse)
+	}))
+	defer server.Close()
+
+	client := NewClient(nil, WithBaseURL(server.URL))
+	config := &Config{Client: client}
+
+	locations, err := config.GetNextLocations()
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+		return
+	}
+	if path != "/location-area" || len(locations.Results) != 2 {
+		t.Errorf("expected the first page from /location-area, got %s with %d results", path, len(locations.Results))
+		return
+	}
+}
+
+func TestClientTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		fmt.Fprint(w, `{}`)
+	}))
+	defer server.Close()
+
+	shared := &http.Client{}
+	client := NewClient(nil, WithBaseURL(server.URL), WithHTTPClient(shared), WithTimeout(20*time.Millisecond))
+
+	if _, err := client.GetPokemon("slowpoke"); err == nil {
+		t.Errorf("expected a timeout error")
+		return
+	}
+	if shared.Timeout != 0 {
+		t.Errorf("expected the shared HTTP client to be left untouched, got timeout %v", shared.Timeout)
+		return
+	}
+}
+
+func TestClientWithoutCache(t *testing.T) {
+	requestCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+		fmt.Fprint(w, `{"name":"mew"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(nil, WithBaseURL(server.URL))
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetPokemon("mew"); err != nil {
+			t.Errorf("expected no error, got %v", err)
+			return
+		}
+	}
+	if requestCount != 2 {
+		t.Errorf("expected every call to reach the server without a cache, got %d requests", requestCount)
+		return
+	}
+}
+
+func TestClientContextCancelsRequest(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient(nil, WithBaseURL(server.URL))
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := client.GetPokemonWithContext(ctx, "snorlax")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+		return
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected cancellation to return promptly, took %v", elapsed)
+		return
+	}
+}
+
+func TestConfigPaginationKeptOnCancel(t *testing.T) {
+	client := NewClient(nil, WithBaseURL("http://127.0.0.1:0"), WithRetryPolicy(RetryPolicy{}))
+	config := &Config{Client: client, Next: "next-page", Previous: "previous-page"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := config.GetNextLocationsWithContext(ctx); err == nil {
+		t.Errorf("expected an error for a cancelled context")
+		return
+	}
+	if config.Next != "next-page" || config.Previous != "previous-page" {
+		t.Errorf("expected pagination to be unchanged, got %q and %q", config.Next, config.Previous)
+		return
+	}
+}
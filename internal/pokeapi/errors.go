@@ -0,0 +1,85 @@
+package pokeapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+var (
+	// ErrNotFound matches responses for resources PokéAPI does not have,
+	// such as a misspelled Pokémon name.
+	ErrNotFound = errors.New("resource not found")
+	// ErrRateLimited matches 429 Too Many Requests responses.
+	ErrRateLimited = errors.New("rate limited")
+	// ErrServer matches 5xx responses.
+	ErrServer = errors.New("server error")
+)
+
+// StatusError is returned for responses without a 2xx status. They are never
+// cached. Use errors.Is with ErrNotFound, ErrRateLimited or ErrServer to
+// check the kind of failure.
+type StatusError struct {
+	URL        string
+	StatusCode int
+	// RetryAfter is the delay the server asked for in a Retry-After header,
+	// or zero.
+	RetryAfter time.Duration
+}
+
+func newStatusError(url string, res *http.Response) *StatusError {
+	return &StatusError{
+		URL:        url,
+		StatusCode: res.StatusCode,
+		RetryAfter: parseRetryAfter(res.Header.Get("Retry-After")),
+	}
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("Unexpected response %d %s for %s", e.StatusCode, http.StatusText(e.StatusCode), e.URL)
+}
+
+func (e *StatusError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServer:
+		return e.StatusCode >= 500
+	}
+	return false
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP
+// date.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(at); wait > 0 {
+			return wait
+		}
+	}
+	return 0
+}
+
+// DecodeError is returned when a response body is not the JSON expected.
+type DecodeError struct {
+	URL string
+	Err error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("Error unmarshaling response from %s: %v", e.URL, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
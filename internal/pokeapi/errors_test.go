@@ -0,0 +1,97 @@
+package pokeapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fyzanshaik/pokedex/internal/pokecache"
+)
+
+func TestStatusErrorsAreTypedAndNotCached(t *testing.T) {
+	cases := []struct {
+		status   int
+		sentinel error
+	}{
+		{status: http.StatusNotFound, sentinel: ErrNotFound},
+		{status: http.StatusTooManyRequests, sentinel: ErrRateLimited},
+		{status: http.StatusInternalServerError, sentinel: ErrServer},
+		{status: http.StatusBadGateway, sentinel: ErrServer},
+	}
+
+	for _, c := range cases {
+		t.Run(http.StatusText(c.status), func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(c.status)
+				fmt.Fprint(w, "Not Found")
+			}))
+			defer server.Close()
+
+			cache := pokecache.NewCache(5 * time.Second)
+			defer cache.Close()
+			client := NewClient(cache, WithBaseURL(server.URL), WithRetryPolicy(RetryPolicy{}))
+
+			_, err := client.GetPokemon("pikachuu")
+			if !errors.Is(err, c.sentinel) {
+				t.Errorf("expected %v, got %v", c.sentinel, err)
+				return
+			}
+
+			var statusErr *StatusError
+			if !errors.As(err, &statusErr) || statusErr.StatusCode != c.status {
+				t.Errorf("expected a StatusError with status %d, got %v", c.status, err)
+				return
+			}
+
+			if _, ok := cache.Peek(server.URL + "/pokemon/pikachuu"); ok {
+				t.Errorf("expected an error response not to be cached")
+				return
+			}
+		})
+	}
+}
+
+func TestStatusErrorIsOnlyItsKind(t *testing.T) {
+	err := error(&StatusError{StatusCode: http.StatusNotFound})
+	if errors.Is(err, ErrServer) || errors.Is(err, ErrRateLimited) {
+		t.Errorf("expected a 404 to only match ErrNotFound")
+	}
+}
+
+func TestStatusErrorRetryAfter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := NewClient(nil, WithBaseURL(server.URL), WithRetryPolicy(RetryPolicy{}))
+
+	_, err := client.GetPokemon("pikachu")
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.RetryAfter != 3*time.Second {
+		t.Errorf("expected a Retry-After of 3s, got %v", err)
+	}
+}
+
+func TestDecodeError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "not json")
+	}))
+	defer server.Close()
+
+	client := NewClient(nil, WithBaseURL(server.URL))
+
+	_, err := client.GetPokemon("pikachu")
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) || decodeErr.URL != server.URL+"/pokemon/pikachu" {
+		t.Errorf("expected a DecodeError for the Pokémon URL, got %v", err)
+		return
+	}
+	if errors.Is(err, ErrNotFound) {
+		t.Errorf("expected a decode error not to match ErrNotFound")
+	}
+}
@@ -0,0 +1,198 @@
+package pokeapi
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PokemonSpecies is what the forms of a Pokémon have in common, including the
+// evolution chain they belong to.
+type PokemonSpecies struct {
+	ID                 int     `json:"id"`
+	Name               string  `json:"name"`
+	Order              int     `json:"order"`
+	BaseHappiness      int     `json:"base_happiness"`
+	CaptureRate        int     `json:"capture_rate"`
+	GenderRate         int     `json:"gender_rate"`
+	HatchCounter       int     `json:"hatch_counter"`
+	IsBaby             bool    `json:"is_baby"`
+	IsLegendary        bool    `json:"is_legendary"`
+	IsMythical         bool    `json:"is_mythical"`
+	Color              Result  `json:"color"`
+	Generation         Result  `json:"generation"`
+	GrowthRate         Result  `json:"growth_rate"`
+	Habitat            *Result `json:"habitat"`
+	EvolvesFromSpecies *Result `json:"evolves_from_species"`
+	EvolutionChain     struct {
+		URL string `json:"url"`
+	} `json:"evolution_chain"`
+	Varieties []struct {
+		IsDefault bool   `json:"is_default"`
+		Pokemon   Result `json:"pokemon"`
+	} `json:"varieties"`
+}
+
+// EvolutionChainID returns the id of the evolution chain of the species, or
+// zero if it has none.
+func (s PokemonSpecies) EvolutionChainID() int {
+	return resourceID(s.EvolutionChain.URL)
+}
+
+// EvolutionChain is the tree of species that evolve into one another,
+// starting from its earliest stage.
+type EvolutionChain struct {
+	ID              int       `json:"id"`
+	BabyTriggerItem *Result   `json:"baby_trigger_item"`
+	Chain           ChainLink `json:"chain"`
+}
+
+// ChainLink is a species in an evolution chain, how it is evolved into and
+// what it evolves to.
+type ChainLink struct {
+	IsBaby  bool   `json:"is_baby"`
+	Species Result `json:"species"`
+	// EvolutionDetails lists the alternative ways of evolving into Species
+	// from the previous link. It is empty for the first link.
+	EvolutionDetails []EvolutionDetail `json:"evolution_details"`
+	EvolvesTo        []ChainLink       `json:"evolves_to"`
+}
+
+// EvolutionDetail is one way of triggering an evolution and the conditions
+// it has. Optional conditions are nil or zero when they do not apply.
+type EvolutionDetail struct {
+	Trigger               Result  `json:"trigger"`
+	Item                  *Result `json:"item"`
+	HeldItem              *Result `json:"held_item"`
+	KnownMove             *Result `json:"known_move"`
+	KnownMoveType         *Result `json:"known_move_type"`
+	Location              *Result `json:"location"`
+	PartySpecies          *Result `json:"party_species"`
+	PartyType             *Result `json:"party_type"`
+	TradeSpecies          *Result `json:"trade_species"`
+	Gender                *int    `json:"gender"`
+	MinLevel              *int    `json:"min_level"`
+	MinHappiness          *int    `json:"min_happiness"`
+	MinBeauty             *int    `json:"min_beauty"`
+	MinAffection          *int    `json:"min_affection"`
+	RelativePhysicalStats *int    `json:"relative_physical_stats"`
+	TimeOfDay             string  `json:"time_of_day"`
+	NeedsOverworldRain    bool    `json:"needs_overworld_rain"`
+	TurnUpsideDown        bool    `json:"turn_upside_down"`
+}
+
+// Describe returns a short description of the evolution, such as "level 16",
+// "use thunder-stone", "trade, holding metal-coat" or
+// "level up, friendship 160+, at night".
+func (d EvolutionDetail) Describe() string {
+	var parts []string
+
+	switch d.Trigger.Name {
+	case "level-up":
+		if d.MinLevel != nil {
+			parts = append(parts, fmt.Sprintf("level %d", *d.MinLevel))
+		} else {
+			parts = append(parts, "level up")
+		}
+	case "use-item":
+		if d.Item != nil {
+			parts = append(parts, "use "+d.Item.Name)
+		} else {
+			parts = append(parts, "use an item")
+		}
+	case "":
+		parts = append(parts, "unknown trigger")
+	default:
+		parts = append(parts, strings.ReplaceAll(d.Trigger.Name, "-", " "))
+		if d.MinLevel != nil {
+			parts = append(parts, fmt.Sprintf("level %d+", *d.MinLevel))
+		}
+	}
+
+	if d.HeldItem != nil {
+		parts = append(parts, "holding "+d.HeldItem.Name)
+	}
+	if d.TradeSpecies != nil {
+		parts = append(parts, "for "+d.TradeSpecies.Name)
+	}
+	if d.MinHappiness != nil {
+		parts = append(parts, fmt.Sprintf("friendship %d+", *d.MinHappiness))
+	}
+	if d.MinAffection != nil {
+		parts = append(parts, fmt.Sprintf("affection %d+", *d.MinAffection))
+	}
+	if d.MinBeauty != nil {
+		parts = append(parts, fmt.Sprintf("beauty %d+", *d.MinBeauty))
+	}
+	if d.KnownMove != nil {
+		parts = append(parts, "knowing "+d.KnownMove.Name)
+	}
+	if d.KnownMoveType != nil {
+		parts = append(parts, "knowing a "+d.KnownMoveType.Name+" move")
+	}
+	if d.PartySpecies != nil {
+		parts = append(parts, "with "+d.PartySpecies.Name+" in the party")
+	}
+	if d.PartyType != nil {
+		parts = append(parts, "with a "+d.PartyType.Name+" type in the party")
+	}
+	if d.Location != nil {
+		parts = append(parts, "at "+d.Location.Name)
+	}
+	switch d.TimeOfDay {
+	case "":
+	case "day":
+		parts = append(parts, "during the day")
+	default:
+		parts = append(parts, "at "+d.TimeOfDay)
+	}
+	if d.Gender != nil {
+		switch *d.Gender {
+		case 1:
+			parts = append(parts, "female")
+		case 2:
+			parts = append(parts, "male")
+		}
+	}
+	if d.RelativePhysicalStats != nil {
+		switch *d.RelativePhysicalStats {
+		case 1:
+			parts = append(parts, "attack > defense")
+		case 0:
+			parts = append(parts, "attack = defense")
+		case -1:
+			parts = append(parts, "attack < defense")
+		}
+	}
+	if d.NeedsOverworldRain {
+		parts = append(parts, "while raining")
+	}
+	if d.TurnUpsideDown {
+		parts = append(parts, "console upside down")
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// GET https://pokeapi.co/api/v2/pokemon-species/{name}/
+func (c *Client) GetPokemonSpecies(speciesName string) (PokemonSpecies, error) {
+	return c.GetPokemonSpeciesWithContext(context.Background(), speciesName)
+}
+
+// GetPokemonSpeciesWithContext is GetPokemonSpecies, giving up once ctx is
+// done.
+func (c *Client) GetPokemonSpeciesWithContext(ctx context.Context, speciesName string) (PokemonSpecies, error) {
+	return fetch[PokemonSpecies](ctx, c, c.baseURL+"/pokemon-species/"+speciesName)
+}
+
+// GET https://pokeapi.co/api/v2/evolution-chain/{id}/
+func (c *Client) GetEvolutionChain(id int) (EvolutionChain, error) {
+	return c.GetEvolutionChainWithContext(context.Background(), id)
+}
+
+// GetEvolutionChainWithContext is GetEvolutionChain, giving up once ctx is
+// done.
+func (c *Client) GetEvolutionChainWithContext(ctx context.Context, id int) (EvolutionChain, error) {
+	return fetch[EvolutionChain](ctx, c, c.baseURL+"/evolution-chain/"+strconv.Itoa(id))
+}
@@ -0,0 +1,148 @@
+package pokeapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var mockSpeciesResponse = `{
+	"id": 133,
+	"name": "eevee",
+	"base_happiness": 50,
+	"capture_rate": 45,
+	"evolves_from_species": null,
+	"evolution_chain": {"url": "https://pokeapi.co/api/v2/evolution-chain/67/"},
+	"varieties": [
+		{"is_default": true, "pokemon": {"name": "eevee", "url": "https://pokeapi.co/api/v2/pokemon/133/"}}
+	]
+}`
+
+var mockEvolutionChainResponse = `{
+	"id": 67,
+	"baby_trigger_item": null,
+	"chain": {
+		"is_baby": false,
+		"species": {"name": "eevee", "url": "https://pokeapi.co/api/v2/pokemon-species/133/"},
+		"evolution_details": [],
+		"evolves_to": [
+			{
+				"is_baby": false,
+				"species": {"name": "vaporeon", "url": "https://pokeapi.co/api/v2/pokemon-species/134/"},
+				"evolution_details": [
+					{"trigger": {"name": "use-item"}, "item": {"name": "water-stone"}, "min_level": null, "time_of_day": ""}
+				],
+				"evolves_to": []
+			},
+			{
+				"is_baby": false,
+				"species": {"name": "umbreon", "url": "https://pokeapi.co/api/v2/pokemon-species/197/"},
+				"evolution_details": [
+					{"trigger": {"name": "level-up"}, "min_happiness": 160, "time_of_day": "night"}
+				],
+				"evolves_to": []
+			}
+		]
+	}
+}`
+
+func TestGetPokemonSpeciesAndEvolutionChain(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/pokemon-species/eevee":
+			fmt.Fprint(w, mockSpeciesResponse)
+		case "/evolution-chain/67":
+			fmt.Fprint(w, mockEvolutionChainResponse)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(nil, WithBaseURL(server.URL))
+
+	species, err := client.GetPokemonSpecies("eevee")
+	if err != nil {
+		t.Errorf("expected no error getting the species, got %v", err)
+		return
+	}
+	if species.EvolvesFromSpecies != nil || species.BaseHappiness != 50 {
+		t.Errorf("expected eevee's species data, got %+v", species)
+		return
+	}
+	if id := species.EvolutionChainID(); id != 67 {
+		t.Errorf("expected evolution chain 67, got %d", id)
+		return
+	}
+
+	chain, err := client.GetEvolutionChain(species.EvolutionChainID())
+	if err != nil {
+		t.Errorf("expected no error getting the chain, got %v", err)
+		return
+	}
+	if chain.Chain.Species.Name != "eevee" || len(chain.Chain.EvolvesTo) != 2 {
+		t.Errorf("expected eevee evolving two ways, got %+v", chain.Chain)
+		return
+	}
+	umbreon := chain.Chain.EvolvesTo[1]
+	if umbreon.Species.Name != "umbreon" || len(umbreon.EvolutionDetails) != 1 {
+		t.Errorf("expected umbreon with one evolution detail, got %+v", umbreon)
+		return
+	}
+	if happiness := umbreon.EvolutionDetails[0].MinHappiness; happiness == nil || *happiness != 160 {
+		t.Errorf("expected a friendship of 160, got %v", happiness)
+	}
+}
+
+func TestEvolutionChainIDWithoutChain(t *testing.T) {
+	if id := (PokemonSpecies{}).EvolutionChainID(); id != 0 {
+		t.Errorf("expected no chain, got %d", id)
+	}
+}
+
+func TestEvolutionDetailDescribe(t *testing.T) {
+	level := 16
+	happiness := 160
+	female := 1
+
+	cases := []struct {
+		detail EvolutionDetail
+		want   string
+	}{
+		{
+			detail: EvolutionDetail{Trigger: Result{Name: "level-up"}, MinLevel: &level},
+			want:   "level 16",
+		},
+		{
+			detail: EvolutionDetail{Trigger: Result{Name: "use-item"}, Item: &Result{Name: "thunder-stone"}},
+			want:   "use thunder-stone",
+		},
+		{
+			detail: EvolutionDetail{Trigger: Result{Name: "trade"}, HeldItem: &Result{Name: "metal-coat"}},
+			want:   "trade, holding metal-coat",
+		},
+		{
+			detail: EvolutionDetail{Trigger: Result{Name: "trade"}, TradeSpecies: &Result{Name: "shelmet"}},
+			want:   "trade, for shelmet",
+		},
+		{
+			detail: EvolutionDetail{Trigger: Result{Name: "level-up"}, MinHappiness: &happiness, TimeOfDay: "day"},
+			want:   "level up, friendship 160+, during the day",
+		},
+		{
+			detail: EvolutionDetail{Trigger: Result{Name: "level-up"}, MinLevel: &level, Gender: &female},
+			want:   "level 16, female",
+		},
+		{
+			detail: EvolutionDetail{Trigger: Result{Name: "three-critical-hits"}},
+			want:   "three critical hits",
+		},
+	}
+
+	for _, c := range cases {
+		if got := c.detail.Describe(); got != c.want {
+			t.Errorf("expected %q, got %q", c.want, got)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package pokeapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/fyzanshaik/pokedex/internal/pokecache"
+)
+
+// fetch returns the decoded resource at fullURL. Every endpoint goes through
+// it, so they all share the cache, middleware, retries and rate limit. Fresh
+// cache hits reuse the value decoded on a previous hit instead of
+// unmarshaling the bytes again.
+func fetch[T any](ctx context.Context, c *Client, fullURL string) (T, error) {
+	key := c.CacheKey(fullURL)
+	if cached, found := pokecache.NewTyped[T](c.cache).Get(key); found {
+		return cached, nil
+	}
+
+	var v T
+	res, err := c.handler(ctx, Request{URL: fullURL, Key: key})
+	if err != nil {
+		return v, err
+	}
+	if err := json.Unmarshal(res.Entry.Val, &v); err != nil {
+		return v, &DecodeError{URL: fullURL, Err: err}
+	}
+	return v, nil
+}
+
+// request makes a single conditional request for req, once the rate limiter
+// allows it. A 304 Not Modified keeps the stale body; other non-2xx responses
+// are returned as a *StatusError.
+func (c *Client) request(ctx context.Context, r Request) (Response, error) {
+	wait, err := c.limiter.wait(ctx)
+	if err != nil {
+		return Response{}, err
+	}
+	if wait > 0 && c.observeWait != nil {
+		c.observeWait(r.URL, wait)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.URL, nil)
+	if err != nil {
+		return Response{}, fmt.Errorf("Error creating request: %w", err)
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+	if r.Stale.ETag != "" {
+		req.Header.Set("If-None-Match", r.Stale.ETag)
+	}
+	if r.Stale.LastModified != "" {
+		req.Header.Set("If-Modified-Since", r.Stale.LastModified)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return Response{}, fmt.Errorf("Error in network request: %w", err)
+	}
+
+	defer res.Body.Close()
+
+	entry := pokecache.Entry{
+		ETag:         res.Header.Get("ETag"),
+		LastModified: res.Header.Get("Last-Modified"),
+	}
+
+	switch {
+	case res.StatusCode == http.StatusNotModified && r.Stale.Val != nil:
+		entry.Val = r.Stale.Val
+		if entry.ETag == "" {
+			entry.ETag = r.Stale.ETag
+		}
+		if entry.LastModified == "" {
+			entry.LastModified = r.Stale.LastModified
+		}
+		return Response{Entry: entry, NotModified: true}, nil
+	case res.StatusCode < 200 || res.StatusCode > 299:
+		return Response{}, newStatusError(r.URL, res)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return Response{}, fmt.Errorf("Error reading Body: %w", err)
+	}
+	entry.Val = body
+	return Response{Entry: entry}, nil
+}
@@ -0,0 +1,202 @@
+package pokeapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fyzanshaik/pokedex/internal/pokecache"
+)
+
+// fetchBody returns the raw body for fullURL through the client pipeline,
+// for tests that check caching without decoding a resource.
+func (c *Client) fetchBody(ctx context.Context, fullURL string) ([]byte, error) {
+	res, err := c.handler(ctx, Request{URL: fullURL, Key: c.CacheKey(fullURL)})
+	if err != nil {
+		return nil, err
+	}
+	return res.Entry.Val, nil
+}
+
+func TestFetchStoresValidators(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+		fmt.Fprint(w, mockLocationAreaResponse)
+	}))
+	defer server.Close()
+
+	cache := pokecache.NewCache(5 * time.Second)
+	defer cache.Close()
+	client := NewClient(cache)
+
+	if _, err := client.fetchBody(context.Background(), server.URL); err != nil {
+		t.Errorf("expected no error, got %v", err)
+		return
+	}
+
+	entry, ok := cache.Peek(server.URL)
+	if !ok {
+		t.Errorf("expected response to be cached")
+		return
+	}
+	if entry.ETag != `"v1"` || entry.LastModified != "Mon, 02 Jan 2006 15:04:05 GMT" {
+		t.Errorf("expected validators to be stored, got %q and %q", entry.ETag, entry.LastModified)
+		return
+	}
+}
+
+func TestFetchRevalidatesStaleEntry(t *testing.T) {
+	var requestCount, notModifiedCount atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount.Add(1)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModifiedCount.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, mockLocationAreaResponse)
+	}))
+	defer server.Close()
+
+	const ttl = 20 * time.Millisecond
+	cache := pokecache.NewCache(ttl, pokecache.WithStaleWindow(time.Hour))
+	defer cache.Close()
+	client := NewClient(cache)
+
+	if _, err := client.fetchBody(context.Background(), server.URL); err != nil {
+		t.Errorf("expected no error on first request, got %v", err)
+		return
+	}
+
+	time.Sleep(ttl * 2)
+
+	// Stale entry is served straight away while the refresh runs
+	body, err := client.fetchBody(context.Background(), server.URL)
+	if err != nil {
+		t.Errorf("expected stale entry to be served, got %v", err)
+		return
+	}
+	if string(body) != mockLocationAreaResponse {
+		t.Errorf("expected stale body to be served")
+		return
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for notModifiedCount.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if notModifiedCount.Load() != 1 {
+		t.Errorf("expected one conditional request answered with 304, got %d", notModifiedCount.Load())
+		return
+	}
+
+	// Wait for the revalidated entry to be stored
+	for time.Now().Before(deadline) {
+		if _, ok := cache.Get(server.URL); ok {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if _, ok := cache.Get(server.URL); !ok {
+		t.Errorf("expected 304 to refresh the cached entry")
+		return
+	}
+	if requestCount.Load() != 2 {
+		t.Errorf("expected 2 requests, got %d", requestCount.Load())
+		return
+	}
+}
+
+func TestFetchStaleRefreshPicksUpNewBody(t *testing.T) {
+	var requestCount atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requestCount.Add(1) == 1 {
+			fmt.Fprint(w, mockLocationAreaResponse)
+			return
+		}
+		fmt.Fprint(w, mockLocationAreaResponsePage2)
+	}))
+	defer server.Close()
+
+	const ttl = 20 * time.Millisecond
+	cache := pokecache.NewCache(ttl, pokecache.WithStaleWindow(time.Hour))
+	defer cache.Close()
+	client := NewClient(cache)
+
+	client.fetchBody(context.Background(), server.URL)
+	time.Sleep(ttl * 2)
+
+	body, _ := client.fetchBody(context.Background(), server.URL)
+	if string(body) != mockLocationAreaResponse {
+		t.Errorf("expected stale body while refreshing")
+		return
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if val, ok := cache.Get(server.URL); ok && string(val) == mockLocationAreaResponsePage2 {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Errorf("expected background refresh to store the new body")
+}
+
+func TestFetchDecodedFromNetworkThenCache(t *testing.T) {
+	var requestCount atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount.Add(1)
+		fmt.Fprint(w, mockLocationAreaResponse)
+	}))
+	defer server.Close()
+
+	cache := pokecache.NewCache(5 * time.Second)
+	defer cache.Close()
+	client := NewClient(cache)
+
+	for i := 0; i < 3; i++ {
+		locations, err := fetch[LocationArea](context.Background(), client, server.URL)
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+			return
+		}
+		if len(locations.Results) != 2 || locations.Results[0].Name != "canalave-city-area" {
+			t.Errorf("expected decoded locations, got %+v", locations)
+			return
+		}
+	}
+
+	if requestCount.Load() != 1 {
+		t.Errorf("expected 1 request, got %d", requestCount.Load())
+		return
+	}
+	if stats := cache.Stats(); stats.Hits != 2 || stats.Misses != 1 {
+		t.Errorf("expected 2 hits and 1 miss, got %+v", stats)
+		return
+	}
+}
+
+func TestFetchDecodedInvalidJSON(t *testing.T) {
+	cache := pokecache.NewCache(5 * time.Second)
+	defer cache.Close()
+	client := NewClient(cache)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "not json")
+	}))
+	defer server.Close()
+
+	if _, err := fetch[Pokemon](context.Background(), client, server.URL); err == nil {
+		t.Errorf("expected unmarshal error for invalid JSON")
+		return
+	}
+}
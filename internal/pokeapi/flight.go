@@ -0,0 +1,83 @@
+package pokeapi
+
+import (
+	"context"
+	"sync"
+)
+
+// flightCall is a fetch in progress whose result is shared by every caller
+// that asked for the same key while it ran.
+type flightCall struct {
+	done chan struct{}
+	val  []byte
+	err  error
+	// waiters counts the callers still waiting for the result. The call is
+	// cancelled once the last of them gives up.
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// flightGroup deduplicates concurrent fetches of the same key.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+// do runs fn once for all concurrent callers with the same key and hands each
+// of them its result. fn gets the values of the first caller's ctx but is only
+// cancelled once every caller waiting for it has given up, so one caller
+// cancelling never fails the others. A caller whose ctx is done stops waiting
+// and gets ctx's error.
+func (g *flightGroup) do(ctx context.Context, key string, fn func(context.Context) ([]byte, error)) ([]byte, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall)
+	}
+	call, ok := g.calls[key]
+	if !ok {
+		callCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		call = &flightCall{done: make(chan struct{}), cancel: cancel}
+		g.calls[key] = call
+		go g.run(callCtx, key, call, fn)
+	}
+	call.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.val, call.err
+	case <-ctx.Done():
+		g.leave(key, call)
+		return nil, ctx.Err()
+	}
+}
+
+func (g *flightGroup) run(ctx context.Context, key string, call *flightCall, fn func(context.Context) ([]byte, error)) {
+	defer call.cancel()
+	call.val, call.err = fn(ctx)
+
+	g.mu.Lock()
+	g.forget(key, call)
+	g.mu.Unlock()
+	close(call.done)
+}
+
+// leave stops a caller waiting for call, cancelling it once nobody waits.
+func (g *flightGroup) leave(key string, call *flightCall) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	call.waiters--
+	if call.waiters == 0 {
+		// Later callers start a new call instead of joining a cancelled one
+		g.forget(key, call)
+		call.cancel()
+	}
+}
+
+// forget removes call from the group unless a newer call replaced it. The
+// caller must hold g.mu.
+func (g *flightGroup) forget(key string, call *flightCall) {
+	if g.calls[key] == call {
+		delete(g.calls, key)
+	}
+}
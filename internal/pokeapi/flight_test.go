@@ -0,0 +1,260 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fyzanshaik/pokedex/internal/pokecache"
+)
+
+func TestFlightGroupSharesResult(t *testing.T) {
+	var group flightGroup
+	var calls atomic.Int32
+	release := make(chan struct{})
+
+	const callers = 10
+	var started, wg sync.WaitGroup
+	started.Add(callers)
+	wg.Add(callers)
+	results := make([][]byte, callers)
+
+	for i := 0; i < callers; i++ {
+		go func(i int) {
+			defer wg.Done()
+			started.Done()
+			results[i], _ = group.do(context.Background(), "key", func(context.Context) ([]byte, error) {
+				calls.Add(1)
+				<-release
+				return []byte("value"), nil
+			})
+		}(i)
+	}
+
+	started.Wait()
+	// Give the waiters time to join the in-flight call
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Errorf("expected fn to run once, ran %d times", calls.Load())
+		return
+	}
+	for i, result := range results {
+		if string(result) != "value" {
+			t.Errorf("expected caller %d to get the shared value, got %q", i, result)
+			return
+		}
+	}
+}
+
+func TestFlightGroupSharesError(t *testing.T) {
+	var group flightGroup
+	expected := errors.New("boom")
+
+	_, err := group.do(context.Background(), "key", func(context.Context) ([]byte, error) {
+		return nil, expected
+	})
+	if !errors.Is(err, expected) {
+		t.Errorf("expected error to be returned, got %v", err)
+		return
+	}
+
+	// The failed call is forgotten so the next caller retries
+	val, err := group.do(context.Background(), "key", func(context.Context) ([]byte, error) {
+		return []byte("value"), nil
+	})
+	if err != nil || string(val) != "value" {
+		t.Errorf("expected retry to succeed, got %q and %v", val, err)
+		return
+	}
+}
+
+func TestFetchCoalescesConcurrentMisses(t *testing.T) {
+	var requestCount atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount.Add(1)
+		time.Sleep(50 * time.Millisecond)
+		fmt.Fprint(w, mockLocationAreaResponse)
+	}))
+	defer server.Close()
+
+	cache := pokecache.NewCache(5 * time.Second)
+	defer cache.Close()
+	client := NewClient(cache)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.fetchBody(context.Background(), server.URL); err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if requestCount.Load() != 1 {
+		t.Errorf("expected concurrent misses to share 1 request, got %d", requestCount.Load())
+		return
+	}
+}
+
+func TestFlightGroupWaiterGivesUpOnCancel(t *testing.T) {
+	var group flightGroup
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	go group.do(context.Background(), "key", func(context.Context) ([]byte, error) {
+		close(started)
+		<-release
+		return []byte("value"), nil
+	})
+	<-started
+	defer close(release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := group.do(ctx, "key", func(context.Context) ([]byte, error) {
+		t.Errorf("expected waiter not to start its own call")
+		return nil, nil
+	}); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestFlightGroupSurvivesFirstCallerCancel(t *testing.T) {
+	var group flightGroup
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error)
+	go func() {
+		_, err := group.do(ctx, "key", func(ctx context.Context) ([]byte, error) {
+			close(started)
+			select {
+			case <-release:
+				return []byte("value"), nil
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		})
+		firstErr <- err
+	}()
+	<-started
+
+	result := make(chan []byte)
+	go func() {
+		val, err := group.do(context.Background(), "key", func(context.Context) ([]byte, error) {
+			t.Errorf("expected the second caller to join the first call")
+			return nil, nil
+		})
+		if err != nil {
+			t.Errorf("expected the second caller to succeed, got %v", err)
+		}
+		result <- val
+	}()
+	// Give the second caller time to join the in-flight call
+	time.Sleep(20 * time.Millisecond)
+
+	cancel()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the first caller to get context.Canceled, got %v", err)
+		return
+	}
+	close(release)
+	if val := <-result; string(val) != "value" {
+		t.Errorf("expected the second caller to get the shared value, got %q", val)
+	}
+}
+
+func TestFlightGroupCancelsOnceEveryCallerLeaves(t *testing.T) {
+	var group flightGroup
+	started := make(chan struct{})
+	cancelled := make(chan struct{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go group.do(ctx, "key", func(ctx context.Context) ([]byte, error) {
+		close(started)
+		<-ctx.Done()
+		close(cancelled)
+		return nil, ctx.Err()
+	})
+	<-started
+
+	cancel()
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Errorf("expected the call to be cancelled once its only caller left")
+		return
+	}
+
+	// A later caller starts a new call rather than joining the cancelled one
+	val, err := group.do(context.Background(), "key", func(context.Context) ([]byte, error) {
+		return []byte("value"), nil
+	})
+	if err != nil || string(val) != "value" {
+		t.Errorf("expected a new call to succeed, got %q and %v", val, err)
+	}
+}
+
+func TestFetchWaiterSurvivesFirstCallerCancel(t *testing.T) {
+	arrived := make(chan struct{}, 1)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		arrived <- struct{}{}
+		<-release
+		fmt.Fprint(w, `{"name":"ditto"}`)
+	}))
+	defer server.Close()
+	defer close(release)
+
+	cache := pokecache.NewCache(5 * time.Second)
+	defer cache.Close()
+	client := NewClient(cache, WithBaseURL(server.URL))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error)
+	go func() {
+		_, err := client.GetPokemonWithContext(ctx, "ditto")
+		firstErr <- err
+	}()
+	<-arrived
+
+	type result struct {
+		pokemon Pokemon
+		err     error
+	}
+	second := make(chan result)
+	go func() {
+		pokemon, err := client.GetPokemonWithContext(context.Background(), "ditto")
+		second <- result{pokemon, err}
+	}()
+	// Give the second caller time to join the in-flight fetch
+	time.Sleep(20 * time.Millisecond)
+
+	cancel()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the first caller to get context.Canceled, got %v", err)
+		return
+	}
+	release <- struct{}{}
+
+	got := <-second
+	if got.err != nil || got.pokemon.Name != "ditto" {
+		t.Errorf("expected the second caller to get ditto, got %+v and %v", got.pokemon, got.err)
+	}
+}
@@ -0,0 +1,113 @@
+package pokeapi
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// CanonicalURL returns the form of rawURL used as its cache key: lowercase
+// scheme and host, no trailing slash and query parameters sorted by name, so
+// .../pokemon/pikachu/ and .../pokemon/pikachu, or offset=20&limit=20 and
+// limit=20&offset=20, share one entry. Unparseable URLs are returned as is.
+func CanonicalURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	u.Path = strings.TrimRight(u.Path, "/")
+	u.RawPath = ""
+	u.Fragment = ""
+	u.RawQuery = u.Query().Encode()
+	u.ForceQuery = false
+
+	return u.String()
+}
+
+// aliasTable maps canonical URLs of resources addressed by id, such as
+// .../pokemon/25, to the URL of the same resource addressed by name, such as
+// .../pokemon/pikachu. It is learned from responses.
+type aliasTable struct {
+	mu   sync.RWMutex
+	byID map[string]string
+}
+
+func (a *aliasTable) learn(idURL, nameURL string) {
+	if idURL == nameURL {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.byID == nil {
+		a.byID = make(map[string]string)
+	}
+	a.byID[idURL] = nameURL
+}
+
+func (a *aliasTable) resolve(key string) string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if nameURL, ok := a.byID[key]; ok {
+		return nameURL
+	}
+	return key
+}
+
+// CacheKey returns the cache key for fullURL: its canonical form, addressed
+// by name when the client has learned the name of the resource.
+func (c *Client) CacheKey(fullURL string) string {
+	return c.aliases.resolve(CanonicalURL(fullURL))
+}
+
+// learnFrom records the id-to-name aliases a response body reveals: the id
+// and name of a single resource fetched from key, and the name and URL of
+// every result of a listing.
+func (a *aliasTable) learnFrom(key string, body []byte) {
+	var resource struct {
+		ID      int      `json:"id"`
+		Name    string   `json:"name"`
+		Results []Result `json:"results"`
+	}
+	if err := json.Unmarshal(body, &resource); err != nil {
+		return
+	}
+
+	if resource.ID > 0 && resource.Name != "" && !strings.Contains(key, "?") {
+		i := strings.LastIndex(key, "/")
+		id := strconv.Itoa(resource.ID)
+		if last := key[i+1:]; i >= 0 && (last == id || last == resource.Name) {
+			a.learn(key[:i]+"/"+id, key[:i]+"/"+resource.Name)
+		}
+	}
+
+	for _, result := range resource.Results {
+		if result.Name == "" || result.URL == "" {
+			continue
+		}
+		idURL := CanonicalURL(result.URL)
+		i := strings.LastIndex(idURL, "/")
+		if i < 0 {
+			continue
+		}
+		if _, err := strconv.Atoi(idURL[i+1:]); err != nil {
+			continue
+		}
+		a.learn(idURL, idURL[:i]+"/"+result.Name)
+	}
+}
+
+// resourceID returns the id at the end of a resource URL such as
+// https://pokeapi.co/api/v2/version-group/25/, or zero if it has none.
+func resourceID(resourceURL string) int {
+	resourceURL = CanonicalURL(resourceURL)
+	id, err := strconv.Atoi(resourceURL[strings.LastIndex(resourceURL, "/")+1:])
+	if err != nil {
+		return 0
+	}
+	return id
+}
@@ -0,0 +1,99 @@
+package pokeapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fyzanshaik/pokedex/internal/pokecache"
+)
+
+func TestCanonicalURL(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		{input: BASE_URL + "/pokemon/pikachu", expected: BASE_URL + "/pokemon/pikachu"},
+		{input: BASE_URL + "/pokemon/pikachu/", expected: BASE_URL + "/pokemon/pikachu"},
+		{input: "HTTPS://PokeAPI.co/api/v2/pokemon/pikachu", expected: BASE_URL + "/pokemon/pikachu"},
+		{input: BASE_URL + "/location-area/?offset=20&limit=20", expected: BASE_URL + "/location-area?limit=20&offset=20"},
+		{input: BASE_URL + "/location-area?limit=20&offset=20", expected: BASE_URL + "/location-area?limit=20&offset=20"},
+		{input: BASE_URL + "/location-area?", expected: BASE_URL + "/location-area"},
+		{input: "not a url\x7f", expected: "not a url\x7f"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.input, func(t *testing.T) {
+			if actual := CanonicalURL(c.input); actual != c.expected {
+				t.Errorf("expected %s, got %s", c.expected, actual)
+				return
+			}
+		})
+	}
+}
+
+func TestLearnAliasesFromResource(t *testing.T) {
+	client := NewClient(nil)
+	base := "http://aliases.test/api/v2"
+	client.aliases.learnFrom(base+"/pokemon/pikachu", []byte(`{"id":25,"name":"pikachu"}`))
+
+	if key := client.CacheKey(base + "/pokemon/25/"); key != base+"/pokemon/pikachu" {
+		t.Errorf("expected id URL to resolve to the name URL, got %s", key)
+		return
+	}
+	if key := client.CacheKey(base + "/pokemon/26"); key != base+"/pokemon/26" {
+		t.Errorf("expected unknown id to stay as is, got %s", key)
+		return
+	}
+}
+
+func TestLearnAliasesFromListing(t *testing.T) {
+	client := NewClient(nil)
+	base := "http://listing.test/api/v2"
+	client.aliases.learnFrom(base+"/location-area", []byte(fmt.Sprintf(
+		`{"results":[{"name":"canalave-city-area","url":%q}]}`, base+"/location-area/1/")))
+
+	if key := client.CacheKey(base + "/location-area/1"); key != base+"/location-area/canalave-city-area" {
+		t.Errorf("expected listing result to be aliased, got %s", key)
+		return
+	}
+}
+
+func TestFetchSharesEntryAcrossURLForms(t *testing.T) {
+	var requestCount atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount.Add(1)
+		fmt.Fprint(w, `{"id":7,"name":"squirtle"}`)
+	}))
+	defer server.Close()
+
+	cache := pokecache.NewCache(5 * time.Second)
+	defer cache.Close()
+	client := NewClient(cache)
+
+	urls := []string{
+		server.URL + "/pokemon/7",
+		server.URL + "/pokemon/squirtle",
+		server.URL + "/pokemon/squirtle/",
+		server.URL + "/pokemon/7/",
+	}
+	for _, url := range urls {
+		if _, err := client.fetchBody(context.Background(), url); err != nil {
+			t.Errorf("expected no error for %s, got %v", url, err)
+			return
+		}
+	}
+
+	if count := requestCount.Load(); count != 1 {
+		t.Errorf("expected 1 request for every form of the URL, got %d", count)
+		return
+	}
+	if keys := cache.Keys(); len(keys) != 1 || keys[0] != server.URL+"/pokemon/squirtle" {
+		t.Errorf("expected a single entry keyed by name, got %v", keys)
+		return
+	}
+}
@@ -0,0 +1,123 @@
+package pokeapi
+
+import (
+	"context"
+
+	"github.com/fyzanshaik/pokedex/internal/pokecache"
+)
+
+// Request is the fetch of a single resource on its way through the pipeline.
+type Request struct {
+	// URL is the full URL of the resource.
+	URL string
+	// Key is the cache key of the resource.
+	Key string
+	// Stale is the cached entry being revalidated, if any. Its validators
+	// are sent with the request.
+	Stale pokecache.Entry
+	// Revalidate asks for a stale entry to be refreshed before returning
+	// instead of being served while it is refreshed in the background.
+	Revalidate bool
+}
+
+// Response is the outcome of a Request.
+type Response struct {
+	// Entry holds the body and its validators.
+	Entry pokecache.Entry
+	// Cached reports that the body came from the cache without a request.
+	Cached bool
+	// NotModified reports that the server confirmed Stale is still current.
+	NotModified bool
+}
+
+// Handler fetches a resource.
+type Handler func(ctx context.Context, req Request) (Response, error)
+
+// Middleware wraps a Handler to add behaviour around fetches, such as
+// logging or metrics.
+type Middleware func(next Handler) Handler
+
+// WithMiddleware adds middleware around every network fetch, outermost
+// first. It runs behind the cache, so cache hits never reach it, and in
+// front of retries, so it sees each fetch once with its final outcome.
+func WithMiddleware(mw ...Middleware) ClientOption {
+	return func(c *Client) {
+		c.middleware = append(c.middleware, mw...)
+	}
+}
+
+// pipeline builds the handler every fetch goes through: the cache, the
+// middleware given with WithMiddleware, retries and finally the request.
+func (c *Client) pipeline() Handler {
+	layers := append([]Middleware{c.cacheLayer}, c.middleware...)
+	layers = append(layers, c.retry.middleware)
+
+	handler := Handler(c.request)
+	for i := len(layers) - 1; i >= 0; i-- {
+		handler = layers[i](handler)
+	}
+	return handler
+}
+
+// cacheLayer answers from the cache when it can and stores whatever the rest
+// of the pipeline fetches, under the name of the resource if the response
+// reveals that the key addresses it by id. A stale entry is served straight
+// away while it is revalidated in the background, which outlives ctx since
+// the caller already has its answer. Concurrent fetches of the same key share
+// a single request and cache insertion, which is only cancelled once every
+// caller sharing it has given up.
+func (c *Client) cacheLayer(next Handler) Handler {
+	store := func(ctx context.Context, req Request) ([]byte, error) {
+		res, err := next(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		if !res.NotModified {
+			c.aliases.learnFrom(req.Key, res.Entry.Val)
+		}
+		pokecache.Put(c.cache, c.aliases.resolve(req.Key), res.Entry)
+		return res.Entry.Val, nil
+	}
+
+	return func(ctx context.Context, req Request) (Response, error) {
+		entry, found := pokecache.Peek(c.cache, req.Key)
+		if found && !entry.Stale() {
+			return Response{Entry: entry, Cached: true}, nil
+		}
+		if found {
+			req.Stale = entry
+			if !req.Revalidate {
+				go c.flights.do(context.WithoutCancel(ctx), req.Key, func(ctx context.Context) ([]byte, error) {
+					return store(ctx, req)
+				})
+				return Response{Entry: entry, Cached: true}, nil
+			}
+		}
+
+		body, err := c.flights.do(ctx, req.Key, func(ctx context.Context) ([]byte, error) {
+			// Another caller may have stored the body between our miss and
+			// taking the flight.
+			if entry, found := pokecache.Peek(c.cache, req.Key); found && !entry.Stale() {
+				return entry.Val, nil
+			}
+			return store(ctx, req)
+		})
+		if err != nil {
+			return Response{}, err
+		}
+		return Response{Entry: pokecache.Entry{Val: body}}, nil
+	}
+}
+
+// middleware retries fetches that fail transiently according to p.
+func (p RetryPolicy) middleware(next Handler) Handler {
+	return func(ctx context.Context, req Request) (Response, error) {
+		var res Response
+		err := p.withRetry(ctx, func() error {
+			var err error
+			res, err = next(ctx, req)
+			return err
+		})
+		return res, err
+	}
+}
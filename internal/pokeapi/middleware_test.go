@@ -0,0 +1,96 @@
+package pokeapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fyzanshaik/pokedex/internal/pokecache"
+)
+
+// recordFetches returns middleware appending name and the URL of every fetch
+// it sees to log.
+func recordFetches(name string, log *[]string) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req Request) (Response, error) {
+			*log = append(*log, name+" "+req.URL)
+			return next(ctx, req)
+		}
+	}
+}
+
+func TestMiddlewareRunsInOrderBehindTheCache(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name":"ditto"}`)
+	}))
+	defer server.Close()
+
+	cache := pokecache.NewCache(5 * time.Second)
+	defer cache.Close()
+
+	var log []string
+	client := NewClient(cache,
+		WithBaseURL(server.URL),
+		WithMiddleware(recordFetches("outer", &log), recordFetches("inner", &log)),
+	)
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetPokemon("ditto"); err != nil {
+			t.Errorf("expected no error, got %v", err)
+			return
+		}
+	}
+
+	url := server.URL + "/pokemon/ditto"
+	want := []string{"outer " + url, "inner " + url}
+	if fmt.Sprint(log) != fmt.Sprint(want) {
+		t.Errorf("expected only the miss to reach the middleware as %v, got %v", want, log)
+	}
+}
+
+func TestMiddlewareSeesRetriedFetchOnce(t *testing.T) {
+	server, requestCount := newFlakyServer(2, func(w http.ResponseWriter) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	defer server.Close()
+
+	var log []string
+	client := NewClient(nil,
+		WithBaseURL(server.URL),
+		WithRetryPolicy(fastRetries),
+		WithMiddleware(recordFetches("fetch", &log)),
+	)
+
+	if _, err := client.GetPokemon("ditto"); err != nil {
+		t.Errorf("expected the retries to succeed, got %v", err)
+		return
+	}
+	if n := requestCount.Load(); n != 3 {
+		t.Errorf("expected 3 requests, got %d", n)
+		return
+	}
+	if len(log) != 1 {
+		t.Errorf("expected the middleware to see one fetch, got %v", log)
+	}
+}
+
+func TestMiddlewareCanAnswerFetches(t *testing.T) {
+	canned := func(next Handler) Handler {
+		return func(ctx context.Context, req Request) (Response, error) {
+			return Response{Entry: pokecache.Entry{Val: []byte(`{"name":"mew"}`)}}, nil
+		}
+	}
+	client := NewClient(nil, WithBaseURL("http://pokeapi.invalid"), WithMiddleware(canned))
+
+	pokemon, err := client.GetPokemon("mew")
+	if err != nil {
+		t.Errorf("expected the middleware to answer, got %v", err)
+		return
+	}
+	if pokemon.Name != "mew" {
+		t.Errorf("expected mew, got %q", pokemon.Name)
+	}
+}
@@ -0,0 +1,175 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DEFAULT_MOVE_WORKERS is the number of concurrent requests GetMoves makes.
+const DEFAULT_MOVE_WORKERS int = 8
+
+// LEARN_METHOD_LEVEL_UP is the learn method of moves learned by levelling up.
+const LEARN_METHOD_LEVEL_UP string = "level-up"
+
+// Move is a move a Pokémon can use in battle. Power, Accuracy and PP are nil
+// for moves that do not have them, such as status moves without a power.
+type Move struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	Power        *int   `json:"power"`
+	Accuracy     *int   `json:"accuracy"`
+	PP           *int   `json:"pp"`
+	Priority     int    `json:"priority"`
+	EffectChance *int   `json:"effect_chance"`
+	Type         Result `json:"type"`
+	DamageClass  Result `json:"damage_class"`
+	Generation   Result `json:"generation"`
+	Target       Result `json:"target"`
+	// EffectEntries describe the effect in each language, with
+	// $effect_chance standing for EffectChance.
+	EffectEntries []struct {
+		Effect      string `json:"effect"`
+		ShortEffect string `json:"short_effect"`
+		Language    Result `json:"language"`
+	} `json:"effect_entries"`
+}
+
+// Effect returns the short English description of the effect of the move,
+// or "" if there is none.
+func (m Move) Effect() string {
+	for _, entry := range m.EffectEntries {
+		if entry.Language.Name != DEFAULT_LANGUAGE {
+			continue
+		}
+		effect := entry.ShortEffect
+		if effect == "" {
+			effect = entry.Effect
+		}
+		if m.EffectChance != nil {
+			effect = strings.ReplaceAll(effect, "$effect_chance", strconv.Itoa(*m.EffectChance))
+		}
+		return strings.Join(strings.Fields(effect), " ")
+	}
+	return ""
+}
+
+// LearnableMove is a move a Pokémon learns in a version group, and how.
+type LearnableMove struct {
+	Name         string
+	Method       string
+	Level        int
+	VersionGroup string
+}
+
+// LatestVersionGroup returns the most recent version group in which the
+// Pokémon learns any move, or "" if it learns none.
+func (p Pokemon) LatestVersionGroup() string {
+	latest, latestID := "", -1
+	for _, move := range p.Moves {
+		for _, detail := range move.VersionGroupDetails {
+			if id := resourceID(detail.VersionGroup.URL); id > latestID {
+				latest, latestID = detail.VersionGroup.Name, id
+			}
+		}
+	}
+	return latest
+}
+
+// Learnset returns the moves the Pokémon learns in versionGroup by method,
+// where an empty method matches every method. Moves learned by levelling up
+// come first, sorted by level, followed by the other methods.
+func (p Pokemon) Learnset(versionGroup, method string) []LearnableMove {
+	var moves []LearnableMove
+	for _, move := range p.Moves {
+		for _, detail := range move.VersionGroupDetails {
+			if detail.VersionGroup.Name != versionGroup {
+				continue
+			}
+			if method != "" && detail.MoveLearnMethod.Name != method {
+				continue
+			}
+			moves = append(moves, LearnableMove{
+				Name:         move.Move.Name,
+				Method:       detail.MoveLearnMethod.Name,
+				Level:        detail.LevelLearnedAt,
+				VersionGroup: versionGroup,
+			})
+		}
+	}
+
+	sort.SliceStable(moves, func(i, j int) bool {
+		a, b := moves[i], moves[j]
+		if levelUpA, levelUpB := a.Method == LEARN_METHOD_LEVEL_UP, b.Method == LEARN_METHOD_LEVEL_UP; levelUpA != levelUpB {
+			return levelUpA
+		}
+		if a.Method != b.Method {
+			return a.Method < b.Method
+		}
+		if a.Level != b.Level {
+			return a.Level < b.Level
+		}
+		return a.Name < b.Name
+	})
+	return moves
+}
+
+// GET https://pokeapi.co/api/v2/move/{name}/
+func (c *Client) GetMove(moveName string) (Move, error) {
+	return c.GetMoveWithContext(context.Background(), moveName)
+}
+
+// GetMoveWithContext is GetMove, giving up once ctx is done.
+func (c *Client) GetMoveWithContext(ctx context.Context, moveName string) (Move, error) {
+	return fetch[Move](ctx, c, c.baseURL+"/move/"+moveName)
+}
+
+// GetMoves fetches the moves named in names concurrently and returns them in
+// the same order. Moves that could not be fetched are left zero and their
+// errors are joined into the returned error.
+func (c *Client) GetMoves(names []string) ([]Move, error) {
+	return c.GetMovesWithContext(context.Background(), names)
+}
+
+// GetMovesWithContext is GetMoves, returning ctx's error once ctx is done.
+func (c *Client) GetMovesWithContext(ctx context.Context, names []string) ([]Move, error) {
+	moves := make([]Move, len(names))
+	errs := make([]error, len(names))
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range min(DEFAULT_MOVE_WORKERS, len(names)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				move, err := c.GetMoveWithContext(ctx, names[i])
+				if err != nil {
+					errs[i] = fmt.Errorf("move %s: %w", names[i], err)
+					continue
+				}
+				moves[i] = move
+			}
+		}()
+	}
+
+send:
+	for i := range names {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			break send
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return moves, err
+	}
+	return moves, errors.Join(errs...)
+}
@@ -0,0 +1,154 @@
+package pokeapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var mockMovesPokemonResponse = `{
+	"name": "pikachu",
+	"moves": [
+		{
+			"move": {"name": "thunderbolt", "url": "https://pokeapi.co/api/v2/move/85/"},
+			"version_group_details": [
+				{"level_learned_at": 0, "move_learn_method": {"name": "machine"}, "version_group": {"name": "red-blue", "url": "https://pokeapi.co/api/v2/version-group/1/"}},
+				{"level_learned_at": 0, "move_learn_method": {"name": "machine"}, "version_group": {"name": "scarlet-violet", "url": "https://pokeapi.co/api/v2/version-group/25/"}}
+			]
+		},
+		{
+			"move": {"name": "thunder-shock", "url": "https://pokeapi.co/api/v2/move/84/"},
+			"version_group_details": [
+				{"level_learned_at": 1, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "red-blue", "url": "https://pokeapi.co/api/v2/version-group/1/"}},
+				{"level_learned_at": 1, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "scarlet-violet", "url": "https://pokeapi.co/api/v2/version-group/25/"}}
+			]
+		},
+		{
+			"move": {"name": "thunder", "url": "https://pokeapi.co/api/v2/move/87/"},
+			"version_group_details": [
+				{"level_learned_at": 43, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "red-blue", "url": "https://pokeapi.co/api/v2/version-group/1/"}}
+			]
+		},
+		{
+			"move": {"name": "quick-attack", "url": "https://pokeapi.co/api/v2/move/98/"},
+			"version_group_details": [
+				{"level_learned_at": 16, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "red-blue", "url": "https://pokeapi.co/api/v2/version-group/1/"}}
+			]
+		}
+	]
+}`
+
+func mockMoveResponse(name string) string {
+	return `{
+		"name": "` + name + `",
+		"power": 40,
+		"accuracy": 100,
+		"pp": 30,
+		"effect_chance": 10,
+		"type": {"name": "electric"},
+		"damage_class": {"name": "special"},
+		"effect_entries": [
+			{"effect": "Long text.", "short_effect": "Has a $effect_chance% chance to paralyze the target.", "language": {"name": "en"}}
+		]
+	}`
+}
+
+func TestLearnset(t *testing.T) {
+	var pokemon Pokemon
+	if err := json.Unmarshal([]byte(mockMovesPokemonResponse), &pokemon); err != nil {
+		t.Errorf("expected the mock to decode, got %v", err)
+		return
+	}
+
+	if latest := pokemon.LatestVersionGroup(); latest != "scarlet-violet" {
+		t.Errorf("expected scarlet-violet to be the latest version group, got %q", latest)
+		return
+	}
+
+	cases := []struct {
+		versionGroup string
+		method       string
+		want         []string
+	}{
+		{versionGroup: "red-blue", want: []string{"thunder-shock", "quick-attack", "thunder", "thunderbolt"}},
+		{versionGroup: "red-blue", method: LEARN_METHOD_LEVEL_UP, want: []string{"thunder-shock", "quick-attack", "thunder"}},
+		{versionGroup: "scarlet-violet", method: "machine", want: []string{"thunderbolt"}},
+		{versionGroup: "gold-silver", want: nil},
+	}
+
+	for _, c := range cases {
+		var got []string
+		for _, move := range pokemon.Learnset(c.versionGroup, c.method) {
+			got = append(got, move.Name)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(c.want) {
+			t.Errorf("expected %v in %s by %q, got %v", c.want, c.versionGroup, c.method, got)
+		}
+	}
+}
+
+func TestMoveEffect(t *testing.T) {
+	var move Move
+	if err := json.Unmarshal([]byte(mockMoveResponse("thunder-shock")), &move); err != nil {
+		t.Errorf("expected the mock to decode, got %v", err)
+		return
+	}
+
+	if effect := move.Effect(); effect != "Has a 10% chance to paralyze the target." {
+		t.Errorf("expected the effect chance to be filled in, got %q", effect)
+	}
+	if effect := (Move{}).Effect(); effect != "" {
+		t.Errorf("expected no effect, got %q", effect)
+	}
+}
+
+func TestGetMovesFetchesConcurrently(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			seen := maxInFlight.Load()
+			if n <= seen || maxInFlight.CompareAndSwap(seen, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		name := strings.TrimPrefix(r.URL.Path, "/move/")
+		if name == "splash-typo" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, mockMoveResponse(name))
+	}))
+	defer server.Close()
+
+	client := NewClient(nil, WithBaseURL(server.URL), WithRateLimit(0, 0))
+
+	names := []string{"thunder-shock", "quick-attack", "splash-typo", "thunder", "thunderbolt"}
+	moves, err := client.GetMoves(names)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected the missing move to be reported, got %v", err)
+		return
+	}
+	for i, name := range names {
+		want := name
+		if name == "splash-typo" {
+			want = ""
+		}
+		if moves[i].Name != want {
+			t.Errorf("expected move %d to be %q, got %q", i, want, moves[i].Name)
+			return
+		}
+	}
+	if maxInFlight.Load() < 2 {
+		t.Errorf("expected moves to be fetched concurrently, got at most %d at once", maxInFlight.Load())
+	}
+}
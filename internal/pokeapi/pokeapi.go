@@ -1,20 +1,18 @@
 package pokeapi
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
-	"net/http"
-
-	"github.com/fyzanshaik/pokedex/internal/pokecache"
 )
 
 const BASE_URL string = "https://pokeapi.co/api/v2"
 
+// Config is the state of a REPL session: the location pagination, the
+// caught Pokémon and the client used to fetch them.
 type Config struct {
 	Next          string
 	Previous      string
-	Cache         *pokecache.Cache
+	Client        *Client
 	CaughtPokemon map[string]Pokemon
 }
 
@@ -365,42 +363,18 @@ type Pokemon struct {
 	Weight int `json:"weight"`
 }
 
-// GET https://pokeapi.co/api/v2/location-area/{id or name}/
-func GetNextLocations(c *Config) (LocationArea, error) {
-	var currentLocationArea LocationArea
-	resourceName := "/location-area"
-	full_url := BASE_URL + resourceName
-
-	if c.Next != "" {
-		full_url = c.Next
-	}
-
-	if cachedData, found := c.Cache.Get(full_url); found {
-		fmt.Println("Accessing cache for: ", full_url)
-		err := json.Unmarshal(cachedData, &currentLocationArea)
-		if err != nil {
-			return LocationArea{}, fmt.Errorf("Error unmarshaling cached data: %w", err)
-		}
-		return currentLocationArea, nil
-	}
-
-	// fmt.Println("Current full url: ", full_url)
-	res, err := http.Get(full_url)
-	if err != nil {
-		return LocationArea{}, fmt.Errorf("Error in network request location-area: %w", err)
-	}
-
-	defer res.Body.Close()
-
-	body, err := io.ReadAll(res.Body)
-	if err != nil {
-		return LocationArea{}, fmt.Errorf("Error reading Body: %w", err)
-	}
+// GetNextLocations returns the next page of location areas, or the first one
+// on the first call, and moves the pagination forward.
+func (c *Config) GetNextLocations() (LocationArea, error) {
+	return c.GetNextLocationsWithContext(context.Background())
+}
 
-	c.Cache.Add(full_url, body)
-	err = json.Unmarshal(body, &currentLocationArea)
+// GetNextLocationsWithContext is GetNextLocations, giving up once ctx is
+// done. The pagination only moves if the page was fetched.
+func (c *Config) GetNextLocationsWithContext(ctx context.Context) (LocationArea, error) {
+	currentLocationArea, err := c.Client.ListLocationAreasWithContext(ctx, c.Next)
 	if err != nil {
-		return LocationArea{}, fmt.Errorf("Error unmarshaling response: %w", err)
+		return LocationArea{}, err
 	}
 
 	if val, ok := currentLocationArea.Previous.(string); ok {
@@ -412,114 +386,25 @@ func GetNextLocations(c *Config) (LocationArea, error) {
 	return currentLocationArea, nil
 }
 
-// GET https://pokeapi.co/api/v2/location-area/{name}/
-func GetLocationInformation(c *Config, locationName string) (LocationInformation, error) {
-	var locationInfo LocationInformation
-	resourceName := "/location-area/" + locationName
-	full_url := BASE_URL + resourceName
-
-	if cachedData, found := c.Cache.Get(full_url); found {
-		fmt.Println("Accessing cache for: ", full_url)
-		err := json.Unmarshal(cachedData, &locationInfo)
-		if err != nil {
-			return LocationInformation{}, fmt.Errorf("Error unmarshaling cached data: %w", err)
-		}
-		return locationInfo, nil
-	}
-
-	res, err := http.Get(full_url)
-	if err != nil {
-		return LocationInformation{}, fmt.Errorf("Error in network request location-area: %w", err)
-	}
-
-	defer res.Body.Close()
-
-	body, err := io.ReadAll(res.Body)
-	if err != nil {
-		return LocationInformation{}, fmt.Errorf("Error reading Body: %w", err)
-	}
-
-	c.Cache.Add(full_url, body)
-
-	err = json.Unmarshal(body, &locationInfo)
-	if err != nil {
-		return LocationInformation{}, fmt.Errorf("Error unmarshaling response: %w", err)
-	}
-
-	return locationInfo, nil
+// GetPrevLocations returns the previous page of location areas and moves the
+// pagination back.
+func (c *Config) GetPrevLocations() (LocationArea, error) {
+	return c.GetPrevLocationsWithContext(context.Background())
 }
 
-// GET https://pokeapi.co/api/v2/pokemon/{name}/
-func GetPokemon(c *Config, pokemonName string) (Pokemon, error) {
-	var pokemon Pokemon
-	resourceName := "/pokemon/" + pokemonName
-	full_url := BASE_URL + resourceName
-
-	if cachedData, found := c.Cache.Get(full_url); found {
-		fmt.Println("Accessing cache for: ", full_url)
-		err := json.Unmarshal(cachedData, &pokemon)
-		if err != nil {
-			return Pokemon{}, fmt.Errorf("Error unmarshaling cached data: %w", err)
-		}
-		return pokemon, nil
-	}
-
-	res, err := http.Get(full_url)
-	if err != nil {
-		return Pokemon{}, fmt.Errorf("Error in network request pokemon: %w", err)
-	}
-
-	defer res.Body.Close()
-
-	body, err := io.ReadAll(res.Body)
-	if err != nil {
-		return Pokemon{}, fmt.Errorf("Error reading Body: %w", err)
-	}
-
-	c.Cache.Add(full_url, body)
-
-	err = json.Unmarshal(body, &pokemon)
-	if err != nil {
-		return Pokemon{}, fmt.Errorf("Error unmarshaling response: %w", err)
-	}
-
-	return pokemon, nil
-}
-
-// GET https://pokeapi.co/api/v2/location-area/{id or name}/
-func GetPrevLocations(c *Config) (LocationArea, error) {
+// GetPrevLocationsWithContext is GetPrevLocations, giving up once ctx is
+// done. The pagination only moves if the page was fetched.
+func (c *Config) GetPrevLocationsWithContext(ctx context.Context) (LocationArea, error) {
 
 	if c.Previous == "" {
 		return LocationArea{}, fmt.Errorf("You are at the first location!")
 	}
-	var currentLocationArea LocationArea
-
-	full_url := c.Previous
-
-	if cachedData, found := c.Cache.Get(full_url); found {
-		err := json.Unmarshal(cachedData, &currentLocationArea)
-		if err != nil {
-			return LocationArea{}, fmt.Errorf("Error unmarshaling cached data: %w", err)
-		}
-		return currentLocationArea, nil
-	}
-
-	// fmt.Println("Current full url: ", full_url)
-	res, err := http.Get(full_url)
-	if err != nil {
-		return LocationArea{}, fmt.Errorf("Error in network request location-area: %w", err)
-	}
-
-	defer res.Body.Close()
 
-	body, err := io.ReadAll(res.Body)
+	currentLocationArea, err := c.Client.ListLocationAreasWithContext(ctx, c.Previous)
 	if err != nil {
-		return LocationArea{}, fmt.Errorf("Error reading Body: %w", err)
+		return LocationArea{}, err
 	}
 
-	c.Cache.Add(full_url, body)
-
-	json.Unmarshal(body, &currentLocationArea)
 	if value, ok := currentLocationArea.Previous.(string); ok {
 		c.Previous = value
 	}
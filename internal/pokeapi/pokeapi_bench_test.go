@@ -0,0 +1,52 @@
+package pokeapi
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/fyzanshaik/pokedex/internal/pokecache"
+)
+
+// loadSamplePokemon reads the full /pokemon/pikachu body kept in tmp/.
+func loadSamplePokemon(b *testing.B) []byte {
+	data, err := os.ReadFile("../../tmp/pokemon.json")
+	if err != nil {
+		b.Skipf("sample pokemon body not available: %v", err)
+	}
+	return data
+}
+
+func BenchmarkPokemonCacheBytes(b *testing.B) {
+	cache := pokecache.NewCache(5 * time.Second)
+	defer cache.Close()
+	key := BASE_URL + "/pokemon/pikachu"
+	cache.Add(key, loadSamplePokemon(b))
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		data, _ := cache.Get(key)
+		var pokemon Pokemon
+		if err := json.Unmarshal(data, &pokemon); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkPokemonCacheDecoded(b *testing.B) {
+	cache := pokecache.NewCache(5 * time.Second)
+	defer cache.Close()
+	key := BASE_URL + "/pokemon/pikachu"
+	cache.Add(key, loadSamplePokemon(b))
+	typed := pokecache.NewTyped[Pokemon](cache)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, ok := typed.Get(key); !ok {
+			b.Fatal("expected cache hit")
+		}
+	}
+}
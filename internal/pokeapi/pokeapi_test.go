@@ -58,11 +58,11 @@ func TestGetNextLocations(t *testing.T) {
 	config := &Config{
 		Next:     server.URL,
 		Previous: "",
-		Cache:    cache,
+		Client:   NewClient(cache),
 	}
 
 	// Test getting locations
-	locations, err := GetNextLocations(config)
+	locations, err := config.GetNextLocations()
 	if err != nil {
 		t.Errorf("expected no error, got %v", err)
 		return
@@ -103,11 +103,11 @@ func TestGetNextLocationsWithCache(t *testing.T) {
 	config := &Config{
 		Next:     server.URL,
 		Previous: "",
-		Cache:    cache,
+		Client:   NewClient(cache),
 	}
 
 	// First request - should hit the server
-	_, err := GetNextLocations(config)
+	_, err := config.GetNextLocations()
 	if err != nil {
 		t.Errorf("expected no error on first request, got %v", err)
 		return
@@ -122,7 +122,7 @@ func TestGetNextLocationsWithCache(t *testing.T) {
 	config.Next = server.URL
 
 	// Second request - should hit the cache
-	_, err = GetNextLocations(config)
+	_, err = config.GetNextLocations()
 	if err != nil {
 		t.Errorf("expected no error on second request, got %v", err)
 		return
@@ -149,11 +149,11 @@ func TestGetPrevLocations(t *testing.T) {
 	config := &Config{
 		Next:     "",
 		Previous: server.URL,
-		Cache:    cache,
+		Client:   NewClient(cache),
 	}
 
 	// Test getting previous locations
-	locations, err := GetPrevLocations(config)
+	locations, err := config.GetPrevLocations()
 	if err != nil {
 		t.Errorf("expected no error, got %v", err)
 		return
@@ -177,11 +177,11 @@ func TestGetPrevLocationsNoPrevious(t *testing.T) {
 	config := &Config{
 		Next:     "",
 		Previous: "",
-		Cache:    cache,
+		Client:   NewClient(cache),
 	}
 
 	// Should return error when no previous exists
-	_, err := GetPrevLocations(config)
+	_, err := config.GetPrevLocations()
 	if err == nil {
 		t.Errorf("expected error when no previous location exists")
 		return
@@ -211,11 +211,11 @@ func TestGetPrevLocationsWithCache(t *testing.T) {
 	config := &Config{
 		Next:     "",
 		Previous: server.URL,
-		Cache:    cache,
+		Client:   NewClient(cache),
 	}
 
 	// First request - should hit the server
-	_, err := GetPrevLocations(config)
+	_, err := config.GetPrevLocations()
 	if err != nil {
 		t.Errorf("expected no error on first request, got %v", err)
 		return
@@ -230,7 +230,7 @@ func TestGetPrevLocationsWithCache(t *testing.T) {
 	config.Previous = server.URL
 
 	// Second request - should hit the cache
-	_, err = GetPrevLocations(config)
+	_, err = config.GetPrevLocations()
 	if err != nil {
 		t.Errorf("expected no error on second request, got %v", err)
 		return
@@ -260,11 +260,11 @@ func TestCacheExpiration(t *testing.T) {
 	config := &Config{
 		Next:     server.URL,
 		Previous: "",
-		Cache:    cache,
+		Client:   NewClient(cache),
 	}
 
 	// First request
-	_, err := GetNextLocations(config)
+	_, err := config.GetNextLocations()
 	if err != nil {
 		t.Errorf("expected no error on first request, got %v", err)
 		return
@@ -282,7 +282,7 @@ func TestCacheExpiration(t *testing.T) {
 	config.Next = server.URL
 
 	// Second request after expiration - should hit the server again
-	_, err = GetNextLocations(config)
+	_, err = config.GetNextLocations()
 	if err != nil {
 		t.Errorf("expected no error on second request, got %v", err)
 		return
@@ -305,11 +305,11 @@ func TestJSONUnmarshalingFromCache(t *testing.T) {
 	config := &Config{
 		Next:     testURL,
 		Previous: "",
-		Cache:    cache,
+		Client:   NewClient(cache),
 	}
 
 	// This should use cached data and unmarshal it correctly
-	locations, err := GetNextLocations(config)
+	locations, err := config.GetNextLocations()
 	if err != nil {
 		t.Errorf("expected no error when using cached data, got %v", err)
 		return
@@ -334,11 +334,11 @@ func TestNetworkError(t *testing.T) {
 	config := &Config{
 		Next:     invalidURL,
 		Previous: "",
-		Cache:    cache,
+		Client:   NewClient(cache),
 	}
 
 	// Should return network error
-	_, err := GetNextLocations(config)
+	_, err := config.GetNextLocations()
 	if err == nil {
 		t.Errorf("expected network error for invalid URL")
 		return
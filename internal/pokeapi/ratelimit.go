@@ -0,0 +1,90 @@
+package pokeapi
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Defaults of the rate limit used unless WithRateLimit is given. They keep
+// bulk operations such as warm within PokéAPI's fair use policy.
+const (
+	DEFAULT_RATE_LIMIT float64 = 10
+	DEFAULT_RATE_BURST int     = 20
+)
+
+// rateLimiter is a token bucket shared by every request of a client. It
+// holds up to burst tokens and refills at rate tokens per second.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newRateLimiter returns a full bucket, or nil, which never waits, for a
+// rate of zero or less.
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	if rate <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// WithRateLimit limits the client to rate requests per second on average,
+// allowing bursts of up to burst requests. A rate of zero or less disables
+// the limit.
+func WithRateLimit(rate float64, burst int) ClientOption {
+	return func(c *Client) {
+		c.limiter = newRateLimiter(rate, burst)
+	}
+}
+
+// WithWaitObserver calls observe with the URL and the time a request spent
+// waiting for the rate limiter, whenever it had to wait.
+func WithWaitObserver(observe func(url string, wait time.Duration)) ClientOption {
+	return func(c *Client) {
+		c.observeWait = observe
+	}
+}
+
+// wait takes a token, sleeping until one is available, and returns how long
+// it slept. If ctx is done first the token is given back.
+func (l *rateLimiter) wait(ctx context.Context) (time.Duration, error) {
+	if l == nil {
+		return 0, nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	l.tokens--
+	delay := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return 0, nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return delay, nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return 0, ctx.Err()
+	}
+}
@@ -0,0 +1,100 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterAllowsBurst(t *testing.T) {
+	limiter := newRateLimiter(1, 3)
+
+	for i := 0; i < 3; i++ {
+		if wait, err := limiter.wait(context.Background()); err != nil || wait != 0 {
+			t.Errorf("expected request %d of the burst not to wait, waited %v (%v)", i, wait, err)
+			return
+		}
+	}
+}
+
+func TestRateLimiterSpacesRequests(t *testing.T) {
+	const rate = 50
+	limiter := newRateLimiter(rate, 1)
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			limiter.wait(context.Background())
+		}()
+	}
+	wg.Wait()
+
+	// The first token is free, the other four are 20ms apart
+	if elapsed := time.Since(start); elapsed < 4*time.Second/rate-5*time.Millisecond {
+		t.Errorf("expected 5 requests at %d/s to take at least 80ms, took %v", rate, elapsed)
+		return
+	}
+}
+
+func TestRateLimiterWaitCancelled(t *testing.T) {
+	limiter := newRateLimiter(0.1, 1)
+	limiter.wait(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := limiter.wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the wait to be cut short, got %v", err)
+	}
+}
+
+func TestRateLimiterDisabled(t *testing.T) {
+	var limiter *rateLimiter = newRateLimiter(0, 1)
+	if limiter != nil {
+		t.Errorf("expected a zero rate to disable the limiter")
+		return
+	}
+	if wait, err := limiter.wait(context.Background()); wait != 0 || err != nil {
+		t.Errorf("expected a nil limiter never to wait, got %v and %v", wait, err)
+	}
+}
+
+func TestClientRateLimitReportsWaits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name":"abra"}`)
+	}))
+	defer server.Close()
+
+	var mu sync.Mutex
+	var waited []string
+	client := NewClient(nil,
+		WithBaseURL(server.URL),
+		WithRateLimit(10, 1),
+		WithWaitObserver(func(url string, wait time.Duration) {
+			mu.Lock()
+			defer mu.Unlock()
+			waited = append(waited, url)
+		}),
+	)
+
+	for _, name := range []string{"abra", "kadabra", "alakazam"} {
+		if _, err := client.GetPokemon(name); err != nil {
+			t.Errorf("expected no error, got %v", err)
+			return
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(waited) != 2 || waited[0] != server.URL+"/pokemon/kadabra" {
+		t.Errorf("expected the 2 requests after the burst to wait, got %v", waited)
+	}
+}
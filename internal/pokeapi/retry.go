@@ -0,0 +1,130 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// Defaults of the retry policy used unless WithRetryPolicy is given.
+const (
+	DEFAULT_RETRY_ATTEMPTS   int           = 3
+	DEFAULT_RETRY_BASE_DELAY time.Duration = 250 * time.Millisecond
+	DEFAULT_RETRY_MAX_DELAY  time.Duration = 5 * time.Second
+)
+
+// RetryPolicy decides how often and how patiently a request is retried after
+// a transient failure: a connection error, 429 Too Many Requests or a 5xx
+// response.
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts including the first one. One
+	// or less disables retries.
+	MaxAttempts int
+	// BaseDelay is the backoff before the first retry. It doubles with each
+	// retry, and the actual delay is picked at random up to that backoff.
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff. A Retry-After longer than MaxDelay is not
+	// waited for and the error is returned instead.
+	MaxDelay time.Duration
+}
+
+// WithRetryPolicy replaces the default retry policy.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+func defaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: DEFAULT_RETRY_ATTEMPTS,
+		BaseDelay:   DEFAULT_RETRY_BASE_DELAY,
+		MaxDelay:    DEFAULT_RETRY_MAX_DELAY,
+	}
+}
+
+// retryable reports whether err is a known transient failure worth another
+// attempt: 429, a 5xx, a connection that was refused or broke off, a timeout
+// or a temporary DNS failure. Anything else, such as a malformed URL or a host
+// that does not exist, fails for good.
+func retryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return errors.Is(err, ErrRateLimited) || errors.Is(err, ErrServer)
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+	// *url.Error is a net.Error itself, even for a URL that does not parse,
+	// so judge what it wraps
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		err = urlErr.Err
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return !dnsErr.IsNotFound && (dnsErr.IsTimeout || dnsErr.IsTemporary)
+	}
+	// Refused and reset connections were handled above; other failures to
+	// connect, such as an unreachable network, only heal if they timed out
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return opErr.Timeout()
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// delay returns how long to wait before retry number retry, counting from
+// zero, after err, and whether to retry at all.
+func (p RetryPolicy) delay(retry int, err error) (time.Duration, bool) {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && statusErr.RetryAfter > 0 {
+		if p.MaxDelay > 0 && statusErr.RetryAfter > p.MaxDelay {
+			return 0, false
+		}
+		return statusErr.RetryAfter, true
+	}
+
+	backoff := p.BaseDelay << retry
+	if backoff <= 0 || (p.MaxDelay > 0 && backoff > p.MaxDelay) {
+		backoff = p.MaxDelay
+	}
+	if backoff <= 0 {
+		return 0, true
+	}
+	return rand.N(backoff) + 1, true
+}
+
+// withRetry calls attempt until it succeeds, fails for good, runs out of
+// attempts or ctx is done.
+func (p RetryPolicy) withRetry(ctx context.Context, attempt func() error) error {
+	var err error
+	for i := 0; ; i++ {
+		err = attempt()
+		if err == nil || i+1 >= p.MaxAttempts || !retryable(err) {
+			return err
+		}
+
+		wait, ok := p.delay(i, err)
+		if !ok {
+			return err
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
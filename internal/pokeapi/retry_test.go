@@ -0,0 +1,241 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+var fastRetries = RetryPolicy{
+	MaxAttempts: 4,
+	BaseDelay:   time.Millisecond,
+	MaxDelay:    10 * time.Millisecond,
+}
+
+// newFlakyServer fails the first failures requests with fail and then
+// answers with a Pokémon body.
+func newFlakyServer(failures int32, fail func(w http.ResponseWriter)) (*httptest.Server, *atomic.Int32) {
+	var requestCount atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requestCount.Add(1) <= failures {
+			fail(w)
+			return
+		}
+		fmt.Fprint(w, `{"name":"ditto"}`)
+	}))
+	return server, &requestCount
+}
+
+func TestRetryRecoversFromTransientFailures(t *testing.T) {
+	cases := []struct {
+		name string
+		fail func(w http.ResponseWriter)
+	}{
+		{name: "server error", fail: func(w http.ResponseWriter) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}},
+		{name: "rate limited", fail: func(w http.ResponseWriter) {
+			w.WriteHeader(http.StatusTooManyRequests)
+		}},
+		{name: "connection reset", fail: func(w http.ResponseWriter) {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err == nil {
+				conn.Close()
+			}
+		}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			server, requestCount := newFlakyServer(2, c.fail)
+			defer server.Close()
+
+			client := NewClient(nil, WithBaseURL(server.URL), WithRetryPolicy(fastRetries))
+
+			pokemon, err := client.GetPokemon("ditto")
+			if err != nil {
+				t.Errorf("expected the third attempt to succeed, got %v", err)
+				return
+			}
+			if pokemon.Name != "ditto" {
+				t.Errorf("expected ditto, got %q", pokemon.Name)
+				return
+			}
+			if n := requestCount.Load(); n != 3 {
+				t.Errorf("expected 3 requests, got %d", n)
+				return
+			}
+		})
+	}
+}
+
+func TestRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	server, requestCount := newFlakyServer(10, func(w http.ResponseWriter) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	defer server.Close()
+
+	client := NewClient(nil, WithBaseURL(server.URL), WithRetryPolicy(fastRetries))
+
+	if _, err := client.GetPokemon("ditto"); !errors.Is(err, ErrServer) {
+		t.Errorf("expected ErrServer, got %v", err)
+		return
+	}
+	if n := requestCount.Load(); n != int32(fastRetries.MaxAttempts) {
+		t.Errorf("expected %d requests, got %d", fastRetries.MaxAttempts, n)
+		return
+	}
+}
+
+func TestRetrySkipsPermanentFailures(t *testing.T) {
+	server, requestCount := newFlakyServer(10, func(w http.ResponseWriter) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	defer server.Close()
+
+	client := NewClient(nil, WithBaseURL(server.URL), WithRetryPolicy(fastRetries))
+
+	if _, err := client.GetPokemon("dito"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+		return
+	}
+	if n := requestCount.Load(); n != 1 {
+		t.Errorf("expected a 404 not to be retried, got %d requests", n)
+		return
+	}
+}
+
+func TestRetryableOnlyTransientErrors(t *testing.T) {
+	noRetries := WithRetryPolicy(RetryPolicy{MaxAttempts: 1})
+
+	_, badURLErr := NewClient(nil, noRetries).GetPokemon("foo%zz")
+	if badURLErr == nil {
+		t.Errorf("expected an invalid URL escape to fail")
+		return
+	}
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	_, refusedErr := NewClient(nil, WithBaseURL(server.URL), noRetries).GetPokemon("ditto")
+	if refusedErr == nil {
+		t.Errorf("expected a closed server to refuse the connection")
+		return
+	}
+
+	// dialError is how the HTTP client reports a failure to connect
+	dialError := func(err error) error {
+		return fmt.Errorf("Error in network request: %w", &url.Error{
+			Op:  "Get",
+			URL: "http://pokeapi.invalid/api/v2/pokemon/ditto",
+			Err: &net.OpError{Op: "dial", Net: "tcp", Err: err},
+		})
+	}
+
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "invalid URL escape", err: badURLErr, want: false},
+		{name: "decode error", err: &DecodeError{URL: "/pokemon/ditto", Err: errors.New("bad json")}, want: false},
+		{name: "unknown error", err: errors.New("something else"), want: false},
+		{name: "not found", err: &StatusError{StatusCode: http.StatusNotFound}, want: false},
+		{name: "rate limited", err: &StatusError{StatusCode: http.StatusTooManyRequests}, want: true},
+		{name: "server error", err: &StatusError{StatusCode: http.StatusBadGateway}, want: true},
+		{name: "connection refused", err: refusedErr, want: true},
+		{name: "no such host", err: dialError(&net.DNSError{Err: "no such host", Name: "pokeapi.invalid", IsNotFound: true}), want: false},
+		{name: "DNS timeout", err: dialError(&net.DNSError{Err: "i/o timeout", Name: "pokeapi.co", IsTimeout: true}), want: true},
+		{name: "network unreachable", err: dialError(syscall.ENETUNREACH), want: false},
+		{name: "connection reset", err: fmt.Errorf("Error in network request: %w", syscall.ECONNRESET), want: true},
+		{name: "truncated body", err: fmt.Errorf("Error reading Body: %w", io.ErrUnexpectedEOF), want: true},
+	}
+	for _, c := range cases {
+		if got := retryable(c.err); got != c.want {
+			t.Errorf("expected retryable(%s) to be %v, got %v for %v", c.name, c.want, got, c.err)
+		}
+	}
+}
+
+func TestRetryHonoursRetryAfter(t *testing.T) {
+	server, _ := newFlakyServer(1, func(w http.ResponseWriter) {
+		w.Header().Set("Retry-After", "1")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	defer server.Close()
+
+	policy := fastRetries
+	policy.MaxDelay = 2 * time.Second
+	client := NewClient(nil, WithBaseURL(server.URL), WithRetryPolicy(policy))
+
+	start := time.Now()
+	if _, err := client.GetPokemon("ditto"); err != nil {
+		t.Errorf("expected the retry to succeed, got %v", err)
+		return
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("expected to wait for Retry-After, waited %v", elapsed)
+		return
+	}
+}
+
+func TestRetryAfterBeyondMaxDelayIsNotWaited(t *testing.T) {
+	server, requestCount := newFlakyServer(1, func(w http.ResponseWriter) {
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	defer server.Close()
+
+	client := NewClient(nil, WithBaseURL(server.URL), WithRetryPolicy(fastRetries))
+
+	if _, err := client.GetPokemon("ditto"); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("expected ErrRateLimited, got %v", err)
+		return
+	}
+	if n := requestCount.Load(); n != 1 {
+		t.Errorf("expected no retry, got %d requests", n)
+		return
+	}
+}
+
+func TestRetryStopsWhenContextIsDone(t *testing.T) {
+	server, _ := newFlakyServer(10, func(w http.ResponseWriter) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	defer server.Close()
+
+	policy := RetryPolicy{MaxAttempts: 5, BaseDelay: time.Minute, MaxDelay: time.Minute}
+	client := NewClient(nil, WithBaseURL(server.URL), WithRetryPolicy(policy))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.GetPokemonWithContext(ctx, "ditto"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the backoff to be cut short, got %v", err)
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	for retry := 0; retry < 10; retry++ {
+		wait, ok := policy.delay(retry, errors.New("connection reset"))
+		if !ok {
+			t.Errorf("expected retry %d to be allowed", retry)
+			return
+		}
+		limit := min(policy.BaseDelay<<retry, policy.MaxDelay)
+		if wait <= 0 || wait > limit {
+			t.Errorf("expected retry %d to wait up to %v, got %v", retry, limit, wait)
+			return
+		}
+	}
+}
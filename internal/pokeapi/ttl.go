@@ -0,0 +1,57 @@
+package pokeapi
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/fyzanshaik/pokedex/internal/pokecache"
+)
+
+// LISTING_TTL applies to paginated listings such as /location-area?offset=20,
+// which change whenever PokéAPI adds resources.
+const LISTING_TTL time.Duration = 10 * time.Minute
+
+// RESOURCE_TTL applies to named resources such as /pokemon/pikachu, which are
+// effectively immutable.
+const RESOURCE_TTL time.Duration = 7 * 24 * time.Hour
+
+// CacheTTL is a pokecache.TTLPolicy for PokéAPI URLs, optionally under a
+// pokecache namespace. Keys that are not PokéAPI v2 URLs return zero so the
+// cache interval applies.
+func CacheTTL(key string) time.Duration {
+	if _, rest, found := strings.Cut(key, pokecache.NAMESPACE_SEPARATOR); found {
+		key = rest
+	}
+
+	u, err := url.Parse(key)
+	if err != nil {
+		return 0
+	}
+
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i, segment := range segments {
+		if segment != "v2" {
+			continue
+		}
+		switch len(segments) - i - 1 {
+		case 0:
+			return 0
+		case 1:
+			return LISTING_TTL
+		default:
+			return RESOURCE_TTL
+		}
+	}
+	return 0
+}
+
+// CacheNamespace returns the cache namespace for an API base URL, its host
+// and path, so a mirror and the real PokéAPI never share cache entries.
+func CacheNamespace(baseURL string) string {
+	u, err := url.Parse(baseURL)
+	if err != nil || u.Host == "" {
+		return baseURL
+	}
+	return u.Host + strings.TrimSuffix(u.Path, "/")
+}
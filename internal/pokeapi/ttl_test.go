@@ -0,0 +1,53 @@
+package pokeapi
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCacheTTL(t *testing.T) {
+	cases := []struct {
+		key      string
+		expected time.Duration
+	}{
+		{key: BASE_URL + "/location-area", expected: LISTING_TTL},
+		{key: BASE_URL + "/location-area/?offset=20&limit=20", expected: LISTING_TTL},
+		{key: BASE_URL + "/location-area/canalave-city-area", expected: RESOURCE_TTL},
+		{key: BASE_URL + "/pokemon/pikachu", expected: RESOURCE_TTL},
+		{key: BASE_URL + "/pokemon/pikachu/", expected: RESOURCE_TTL},
+		{key: BASE_URL, expected: 0},
+		{key: "http://127.0.0.1:8080", expected: 0},
+		{key: "not a url\x7f", expected: 0},
+		{key: "pokeapi.co/api/v2|" + BASE_URL + "/pokemon/pikachu", expected: RESOURCE_TTL},
+		{key: "pokeapi.co/api/v2|" + BASE_URL + "/location-area?offset=20", expected: LISTING_TTL},
+	}
+
+	for _, c := range cases {
+		t.Run(c.key, func(t *testing.T) {
+			if ttl := CacheTTL(c.key); ttl != c.expected {
+				t.Errorf("expected TTL %v for %s, got %v", c.expected, c.key, ttl)
+				return
+			}
+		})
+	}
+}
+
+func TestCacheNamespace(t *testing.T) {
+	cases := []struct {
+		baseURL  string
+		expected string
+	}{
+		{baseURL: BASE_URL, expected: "pokeapi.co/api/v2"},
+		{baseURL: BASE_URL + "/", expected: "pokeapi.co/api/v2"},
+		{baseURL: "http://127.0.0.1:8080/api/v2", expected: "127.0.0.1:8080/api/v2"},
+		{baseURL: "local", expected: "local"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.baseURL, func(t *testing.T) {
+			if ns := CacheNamespace(c.baseURL); ns != c.expected {
+				t.Errorf("expected namespace %q for %s, got %q", c.expected, c.baseURL, ns)
+			}
+		})
+	}
+}
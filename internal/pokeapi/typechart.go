@@ -0,0 +1,130 @@
+package pokeapi
+
+import "context"
+
+// Type is an elemental type and how it fares against the other types.
+type Type struct {
+	ID              int             `json:"id"`
+	Name            string          `json:"name"`
+	DamageRelations DamageRelations `json:"damage_relations"`
+	Pokemon         []struct {
+		Slot    int    `json:"slot"`
+		Pokemon Result `json:"pokemon"`
+	} `json:"pokemon"`
+}
+
+// DamageRelations lists the types a type deals or takes double, half or no
+// damage from. Types it is neutral against are not listed.
+type DamageRelations struct {
+	DoubleDamageFrom []Result `json:"double_damage_from"`
+	DoubleDamageTo   []Result `json:"double_damage_to"`
+	HalfDamageFrom   []Result `json:"half_damage_from"`
+	HalfDamageTo     []Result `json:"half_damage_to"`
+	NoDamageFrom     []Result `json:"no_damage_from"`
+	NoDamageTo       []Result `json:"no_damage_to"`
+}
+
+// TypeNames returns the names of the types of the Pokémon in slot order.
+func (p Pokemon) TypeNames() []string {
+	names := make([]string, len(p.Types))
+	for i, typeInfo := range p.Types {
+		names[i] = typeInfo.Type.Name
+	}
+	return names
+}
+
+// TypeChart computes type effectiveness locally from the damage relations of
+// the types it was built from. It only knows the relations those types
+// reveal: as an attacker through their *_to lists and as a defender through
+// their *_from lists. Anything else is neutral.
+type TypeChart struct {
+	// multipliers maps attacking type to defending type to multiplier.
+	multipliers map[string]map[string]float64
+}
+
+// NewTypeChart returns the chart of the relations of types.
+func NewTypeChart(types ...Type) TypeChart {
+	chart := TypeChart{multipliers: make(map[string]map[string]float64)}
+	for _, t := range types {
+		relations := t.DamageRelations
+		for _, r := range relations.DoubleDamageTo {
+			chart.set(t.Name, r.Name, 2)
+		}
+		for _, r := range relations.HalfDamageTo {
+			chart.set(t.Name, r.Name, 0.5)
+		}
+		for _, r := range relations.NoDamageTo {
+			chart.set(t.Name, r.Name, 0)
+		}
+		for _, r := range relations.DoubleDamageFrom {
+			chart.set(r.Name, t.Name, 2)
+		}
+		for _, r := range relations.HalfDamageFrom {
+			chart.set(r.Name, t.Name, 0.5)
+		}
+		for _, r := range relations.NoDamageFrom {
+			chart.set(r.Name, t.Name, 0)
+		}
+	}
+	return chart
+}
+
+func (t TypeChart) set(attacking, defending string, multiplier float64) {
+	if t.multipliers[attacking] == nil {
+		t.multipliers[attacking] = make(map[string]float64)
+	}
+	t.multipliers[attacking][defending] = multiplier
+}
+
+// Multiplier returns the damage multiplier of a move of the attacking type
+// against a Pokémon of the defending types, e.g. 4 for ground against
+// fire/steel or 0 for ground against anything flying.
+func (t TypeChart) Multiplier(attacking string, defending ...string) float64 {
+	multiplier := 1.0
+	for _, d := range defending {
+		if m, ok := t.multipliers[attacking][d]; ok {
+			multiplier *= m
+		}
+	}
+	return multiplier
+}
+
+// Matchups returns the multiplier of every attacking type the chart knows
+// against the defending types, leaving out neutral ones.
+func (t TypeChart) Matchups(defending ...string) map[string]float64 {
+	matchups := make(map[string]float64)
+	for attacking := range t.multipliers {
+		if m := t.Multiplier(attacking, defending...); m != 1 {
+			matchups[attacking] = m
+		}
+	}
+	return matchups
+}
+
+// GET https://pokeapi.co/api/v2/type/{name}/
+func (c *Client) GetType(typeName string) (Type, error) {
+	return c.GetTypeWithContext(context.Background(), typeName)
+}
+
+// GetTypeWithContext is GetType, giving up once ctx is done.
+func (c *Client) GetTypeWithContext(ctx context.Context, typeName string) (Type, error) {
+	return fetch[Type](ctx, c, c.baseURL+"/type/"+typeName)
+}
+
+// TypeChart fetches the types named in typeNames and returns their chart.
+func (c *Client) TypeChart(typeNames ...string) (TypeChart, error) {
+	return c.TypeChartWithContext(context.Background(), typeNames...)
+}
+
+// TypeChartWithContext is TypeChart, giving up once ctx is done.
+func (c *Client) TypeChartWithContext(ctx context.Context, typeNames ...string) (TypeChart, error) {
+	types := make([]Type, len(typeNames))
+	for i, name := range typeNames {
+		t, err := c.GetTypeWithContext(ctx, name)
+		if err != nil {
+			return TypeChart{}, err
+		}
+		types[i] = t
+	}
+	return NewTypeChart(types...), nil
+}
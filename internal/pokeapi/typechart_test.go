@@ -0,0 +1,143 @@
+package pokeapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+var mockTypeResponses = map[string]string{
+	"fire": `{
+		"id": 10,
+		"name": "fire",
+		"damage_relations": {
+			"double_damage_from": [{"name": "ground"}, {"name": "rock"}, {"name": "water"}],
+			"double_damage_to": [{"name": "bug"}, {"name": "steel"}, {"name": "grass"}, {"name": "ice"}],
+			"half_damage_from": [{"name": "bug"}, {"name": "steel"}, {"name": "fire"}, {"name": "grass"}, {"name": "ice"}, {"name": "fairy"}],
+			"half_damage_to": [{"name": "rock"}, {"name": "fire"}, {"name": "water"}, {"name": "dragon"}],
+			"no_damage_from": [],
+			"no_damage_to": []
+		}
+	}`,
+	"flying": `{
+		"id": 3,
+		"name": "flying",
+		"damage_relations": {
+			"double_damage_from": [{"name": "electric"}, {"name": "ice"}, {"name": "rock"}],
+			"double_damage_to": [{"name": "fighting"}, {"name": "bug"}, {"name": "grass"}],
+			"half_damage_from": [{"name": "fighting"}, {"name": "bug"}, {"name": "grass"}],
+			"half_damage_to": [{"name": "rock"}, {"name": "steel"}, {"name": "electric"}],
+			"no_damage_from": [{"name": "ground"}],
+			"no_damage_to": []
+		}
+	}`,
+	"ground": `{
+		"id": 5,
+		"name": "ground",
+		"damage_relations": {
+			"double_damage_from": [{"name": "water"}, {"name": "grass"}, {"name": "ice"}],
+			"double_damage_to": [{"name": "poison"}, {"name": "rock"}, {"name": "steel"}, {"name": "fire"}, {"name": "electric"}],
+			"half_damage_from": [{"name": "poison"}, {"name": "rock"}],
+			"half_damage_to": [{"name": "bug"}, {"name": "grass"}],
+			"no_damage_from": [{"name": "electric"}],
+			"no_damage_to": [{"name": "flying"}]
+		}
+	}`,
+}
+
+func newTypeServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := mockTypeResponses[strings.TrimPrefix(r.URL.Path, "/type/")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, body)
+	}))
+}
+
+func TestGetType(t *testing.T) {
+	server := newTypeServer()
+	defer server.Close()
+
+	fire, err := NewClient(nil, WithBaseURL(server.URL)).GetType("fire")
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+		return
+	}
+	if len(fire.DamageRelations.DoubleDamageFrom) != 3 || fire.DamageRelations.HalfDamageTo[3].Name != "dragon" {
+		t.Errorf("expected fire's damage relations, got %+v", fire.DamageRelations)
+	}
+}
+
+func TestTypeChartDualTypeWeaknesses(t *testing.T) {
+	server := newTypeServer()
+	defer server.Close()
+
+	chart, err := NewClient(nil, WithBaseURL(server.URL)).TypeChart("fire", "flying")
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+		return
+	}
+
+	want := map[string]float64{
+		"rock":     4,
+		"water":    2,
+		"electric": 2,
+		"fire":     0.5,
+		"fighting": 0.5,
+		"steel":    0.5,
+		"fairy":    0.5,
+		"bug":      0.25,
+		"grass":    0.25,
+		"ground":   0,
+	}
+	got := chart.Matchups("fire", "flying")
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("expected charizard's matchups to be %v, got %v", want, got)
+		return
+	}
+	if m := chart.Multiplier("ice", "fire", "flying"); m != 1 {
+		t.Errorf("expected a weakness and a resistance to cancel out, got %v", m)
+	}
+}
+
+func TestTypeChartAttackingMultiplier(t *testing.T) {
+	server := newTypeServer()
+	defer server.Close()
+
+	ground, err := NewClient(nil, WithBaseURL(server.URL)).GetType("ground")
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+		return
+	}
+	chart := NewTypeChart(ground)
+
+	cases := []struct {
+		defending []string
+		want      float64
+	}{
+		{defending: []string{"fire", "steel"}, want: 4},
+		{defending: []string{"electric"}, want: 2},
+		{defending: []string{"grass"}, want: 0.5},
+		{defending: []string{"fire", "flying"}, want: 0},
+		{defending: []string{"water"}, want: 1},
+		{defending: nil, want: 1},
+	}
+	for _, c := range cases {
+		if got := chart.Multiplier("ground", c.defending...); got != c.want {
+			t.Errorf("expected ground against %v to be %vx, got %vx", c.defending, c.want, got)
+		}
+	}
+}
+
+func TestTypeChartUnknownType(t *testing.T) {
+	server := newTypeServer()
+	defer server.Close()
+
+	if _, err := NewClient(nil, WithBaseURL(server.URL)).TypeChart("fire", "shadowy"); err == nil {
+		t.Errorf("expected an unknown type to fail the chart")
+	}
+}
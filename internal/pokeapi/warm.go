@@ -0,0 +1,221 @@
+package pokeapi
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+)
+
+// DEFAULT_WARM_WORKERS is the number of concurrent requests Warm makes unless
+// told otherwise.
+const DEFAULT_WARM_WORKERS int = 8
+
+// Warm stages, reported in WarmProgress.Stage.
+const (
+	WARM_STAGE_AREAS   string = "areas"
+	WARM_STAGE_POKEMON string = "pokemon"
+)
+
+// WarmProgress is a snapshot of a running Warm. Total may grow while the
+// location listing is still being walked.
+type WarmProgress struct {
+	Stage string
+	Done  int
+	Total int
+}
+
+// WarmReport summarizes a Warm run.
+type WarmReport struct {
+	Areas   int
+	Pokemon int
+	// Cached counts resources that were already fresh in the cache and
+	// needed no request.
+	Cached int
+	// Failed lists the URLs that could not be fetched. Running Warm again
+	// retries them.
+	Failed []string
+}
+
+// Warm walks the /location-area listing and fetches every location area and
+// every Pokémon encountered in them into the cache, using up to workers
+// concurrent requests. Fresh cache entries are skipped, so an interrupted or
+// partially failed run resumes where it left off when run again. progress,
+// if not nil, is called after each resource; calls are serialized.
+func (c *Client) Warm(workers int, progress func(WarmProgress)) (WarmReport, error) {
+	return c.WarmWithContext(context.Background(), workers, progress)
+}
+
+// WarmWithContext is Warm, stopping once ctx is done with the report of the
+// work finished so far and ctx's error.
+func (c *Client) WarmWithContext(ctx context.Context, workers int, progress func(WarmProgress)) (WarmReport, error) {
+	if workers < 1 {
+		workers = 1
+	}
+	w := &warmer{
+		client:   c,
+		workers:  workers,
+		progress: progress,
+		pokemon:  make(map[string]bool),
+	}
+
+	// The stage starts before the walker can report the area count
+	w.start(WARM_STAGE_AREAS, 0)
+	areas := make(chan string)
+	var walkErr error
+	go func() {
+		defer close(areas)
+		walkErr = w.walkAreas(ctx, areas)
+	}()
+	w.run(ctx, areas, w.collectPokemon)
+	if walkErr != nil {
+		return w.report, walkErr
+	}
+
+	names := make([]string, 0, len(w.pokemon))
+	for name := range w.pokemon {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	w.start(WARM_STAGE_POKEMON, len(names))
+	pokemon := make(chan string)
+	go func() {
+		defer close(pokemon)
+		for _, name := range names {
+			select {
+			case pokemon <- c.baseURL + "/pokemon/" + name:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	w.run(ctx, pokemon, nil)
+
+	return w.report, ctx.Err()
+}
+
+type warmer struct {
+	client   *Client
+	workers  int
+	progress func(WarmProgress)
+
+	mu      sync.Mutex
+	current WarmProgress
+	report  WarmReport
+	pokemon map[string]bool
+}
+
+// walkAreas follows the listing pagination and sends the URL of every
+// location area, keyed the same way GetLocationInformation keys them.
+func (w *warmer) walkAreas(ctx context.Context, areas chan<- string) error {
+	baseURL := w.client.baseURL
+	pageURL := baseURL + "/location-area"
+	for pageURL != "" {
+		body, _, err := w.client.warmFetch(ctx, pageURL)
+		if err != nil {
+			return err
+		}
+
+		var page LocationArea
+		if err := json.Unmarshal(body, &page); err != nil {
+			return &DecodeError{URL: pageURL, Err: err}
+		}
+
+		w.mu.Lock()
+		w.current.Total = page.Count
+		w.mu.Unlock()
+
+		for _, result := range page.Results {
+			select {
+			case areas <- baseURL + "/location-area/" + result.Name:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		pageURL = page.Next
+	}
+	return nil
+}
+
+// collectPokemon records the Pokémon encountered in an area body.
+func (w *warmer) collectPokemon(url string, body []byte) error {
+	var area LocationInformation
+	if err := json.Unmarshal(body, &area); err != nil {
+		return &DecodeError{URL: url, Err: err}
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, encounter := range area.PokemonEncounters {
+		w.pokemon[encounter.Pokemon.Name] = true
+	}
+	return nil
+}
+
+// start resets the progress for stage, expecting total resources.
+func (w *warmer) start(stage string, total int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.current = WarmProgress{Stage: stage, Total: total}
+}
+
+// run fetches every URL from urls with the worker pool, passing successful
+// bodies to handle, until urls is closed. Fetches cut short by ctx are not
+// reported.
+func (w *warmer) run(ctx context.Context, urls <-chan string, handle func(string, []byte) error) {
+	var wg sync.WaitGroup
+	for i := 0; i < w.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for url := range urls {
+				body, cached, err := w.client.warmFetch(ctx, url)
+				if ctx.Err() != nil {
+					continue
+				}
+				if err == nil && handle != nil {
+					err = handle(url, body)
+				}
+				w.record(url, cached, err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func (w *warmer) record(url string, cached bool, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	switch {
+	case err != nil:
+		w.report.Failed = append(w.report.Failed, url)
+	case w.current.Stage == WARM_STAGE_AREAS:
+		w.report.Areas++
+	default:
+		w.report.Pokemon++
+	}
+	if err == nil && cached {
+		w.report.Cached++
+	}
+
+	w.current.Done++
+	if w.current.Total < w.current.Done {
+		w.current.Total = w.current.Done
+	}
+	if w.progress != nil {
+		w.progress(w.current)
+	}
+}
+
+// warmFetch returns the body for fullURL, reporting whether a fresh cache
+// entry made the request unnecessary. Unlike fetch it revalidates stale
+// entries before returning, so a warmed cache is fresh.
+func (c *Client) warmFetch(ctx context.Context, fullURL string) ([]byte, bool, error) {
+	res, err := c.handler(ctx, Request{URL: fullURL, Key: c.CacheKey(fullURL), Revalidate: true})
+	if err != nil {
+		return nil, false, err
+	}
+	return res.Entry.Val, res.Cached, nil
+}
@@ -0,0 +1,218 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fyzanshaik/pokedex/internal/pokecache"
+)
+
+// newWarmServer serves a two-page location listing with three areas sharing
+// four Pokémon, counting the requests made for each path.
+func newWarmServer(t *testing.T) (*httptest.Server, *sync.Map) {
+	t.Helper()
+
+	encounters := map[string][]string{
+		"canalave-city-area": {"tentacool", "wingull"},
+		"eterna-city-area":   {"wingull", "shellos"},
+		"floaroma-town-area": {"pikachu"},
+	}
+
+	var requests sync.Map
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count, _ := requests.LoadOrStore(r.URL.Path, new(atomic.Int32))
+		count.(*atomic.Int32).Add(1)
+
+		switch {
+		case r.URL.Path == "/location-area" && r.URL.Query().Get("offset") == "":
+			fmt.Fprintf(w, `{"count":3,"next":%q,"results":[{"name":"canalave-city-area"},{"name":"eterna-city-area"}]}`,
+				server.URL+"/location-area?offset=2")
+		case r.URL.Path == "/location-area":
+			fmt.Fprint(w, `{"count":3,"next":"","results":[{"name":"floaroma-town-area"}]}`)
+		case strings.HasPrefix(r.URL.Path, "/location-area/"):
+			var parts []string
+			for _, name := range encounters[strings.TrimPrefix(r.URL.Path, "/location-area/")] {
+				parts = append(parts, fmt.Sprintf(`{"pokemon":{"name":%q}}`, name))
+			}
+			fmt.Fprintf(w, `{"pokemon_encounters":[%s]}`, strings.Join(parts, ","))
+		case strings.HasPrefix(r.URL.Path, "/pokemon/"):
+			fmt.Fprintf(w, `{"name":%q}`, strings.TrimPrefix(r.URL.Path, "/pokemon/"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	return server, &requests
+}
+
+func countRequests(requests *sync.Map) int {
+	total := 0
+	requests.Range(func(key, value any) bool {
+		total += int(value.(*atomic.Int32).Load())
+		return true
+	})
+	return total
+}
+
+func TestWarmFetchesAreasAndPokemon(t *testing.T) {
+	server, requests := newWarmServer(t)
+	defer server.Close()
+
+	cache := pokecache.NewCache(time.Minute)
+	defer cache.Close()
+	client := NewClient(cache, WithBaseURL(server.URL))
+
+	var updates []WarmProgress
+	report, err := client.Warm(3, func(p WarmProgress) {
+		updates = append(updates, p)
+	})
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+		return
+	}
+
+	if report.Areas != 3 || report.Pokemon != 4 || report.Cached != 0 || len(report.Failed) != 0 {
+		t.Errorf("unexpected report %+v", report)
+		return
+	}
+	if len(updates) != 7 {
+		t.Errorf("expected 7 progress updates, got %d", len(updates))
+		return
+	}
+	if last := updates[len(updates)-1]; last.Stage != WARM_STAGE_POKEMON || last.Done != 4 || last.Total != 4 {
+		t.Errorf("unexpected final progress %+v", last)
+		return
+	}
+
+	for _, key := range []string{
+		server.URL + "/location-area/eterna-city-area",
+		server.URL + "/pokemon/wingull",
+	} {
+		if _, ok := cache.Get(key); !ok {
+			t.Errorf("expected %s to be cached", key)
+			return
+		}
+	}
+
+	count, _ := requests.Load("/pokemon/wingull")
+	if n := count.(*atomic.Int32).Load(); n != 1 {
+		t.Errorf("expected a shared Pokémon to be fetched once, got %d", n)
+		return
+	}
+}
+
+func TestWarmResumesFromCache(t *testing.T) {
+	server, requests := newWarmServer(t)
+	defer server.Close()
+
+	cache := pokecache.NewCache(time.Minute)
+	defer cache.Close()
+	client := NewClient(cache, WithBaseURL(server.URL))
+
+	cache.Add(server.URL+"/location-area/canalave-city-area",
+		[]byte(`{"pokemon_encounters":[{"pokemon":{"name":"tentacool"}}]}`))
+	cache.Add(server.URL+"/pokemon/tentacool", []byte(`{"name":"tentacool"}`))
+
+	report, err := client.Warm(2, nil)
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+		return
+	}
+	if report.Cached != 2 {
+		t.Errorf("expected 2 cached resources, got %d", report.Cached)
+		return
+	}
+	if _, ok := requests.Load("/location-area/canalave-city-area"); ok {
+		t.Errorf("expected the cached area not to be requested")
+		return
+	}
+
+	before := countRequests(requests)
+	report, err = client.Warm(2, nil)
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+		return
+	}
+	if after := countRequests(requests); after != before {
+		t.Errorf("expected a second run to make no requests, made %d", after-before)
+		return
+	}
+	if report.Cached != report.Areas+report.Pokemon {
+		t.Errorf("expected everything to be cached, got %+v", report)
+		return
+	}
+}
+
+func TestWarmReportsAreaTotalFromCachedListing(t *testing.T) {
+	server, _ := newWarmServer(t)
+	defer server.Close()
+
+	cache := pokecache.NewCache(time.Minute)
+	defer cache.Close()
+	client := NewClient(cache, WithBaseURL(server.URL))
+
+	if _, err := client.Warm(2, nil); err != nil {
+		t.Errorf("expected no error, got %v", err)
+		return
+	}
+
+	// The cached listing is walked at once, before the workers start
+	var updates []WarmProgress
+	if _, err := client.Warm(2, func(p WarmProgress) {
+		updates = append(updates, p)
+	}); err != nil {
+		t.Errorf("expected no error, got %v", err)
+		return
+	}
+	for _, p := range updates {
+		if p.Stage == WARM_STAGE_AREAS && p.Total != 3 {
+			t.Errorf("expected the area total from the listing, got %+v", p)
+			return
+		}
+	}
+}
+
+func TestWarmReportsListingError(t *testing.T) {
+	cache := pokecache.NewCache(time.Minute)
+	defer cache.Close()
+	client := NewClient(cache, WithBaseURL("http://127.0.0.1:0"), WithRetryPolicy(RetryPolicy{}))
+
+	if _, err := client.Warm(2, nil); err == nil {
+		t.Errorf("expected an error for an unreachable listing")
+		return
+	}
+}
+
+func TestWarmStopsOnCancel(t *testing.T) {
+	server, requests := newWarmServer(t)
+	defer server.Close()
+
+	cache := pokecache.NewCache(time.Minute)
+	defer cache.Close()
+	client := NewClient(cache, WithBaseURL(server.URL))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	report, err := client.WarmWithContext(ctx, 1, func(p WarmProgress) {
+		cancel()
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+		return
+	}
+	if len(report.Failed) != 0 {
+		t.Errorf("expected cancelled fetches not to be reported as failures, got %v", report.Failed)
+		return
+	}
+	if _, ok := requests.Load("/pokemon/pikachu"); ok {
+		t.Errorf("expected no Pokémon to be fetched after cancelling")
+		return
+	}
+}
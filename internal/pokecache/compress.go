@@ -0,0 +1,61 @@
+package pokecache
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+)
+
+// WithCompression gzips values of at least threshold bytes before storing
+// them in memory and on disk. Values that do not shrink are stored as is.
+// Get and Peek always return the original bytes.
+func WithCompression(threshold int) Option {
+	return func(c *Cache) {
+		c.compressAt = threshold
+	}
+}
+
+// compress returns the gzipped form of val and true when it is worth
+// storing compressed.
+func compress(val []byte) ([]byte, bool) {
+	var buf bytes.Buffer
+	zw, err := gzip.NewWriterLevel(&buf, gzip.BestSpeed)
+	if err != nil {
+		return val, false
+	}
+	if _, err := zw.Write(val); err != nil {
+		return val, false
+	}
+	if err := zw.Close(); err != nil {
+		return val, false
+	}
+	if buf.Len() >= len(val) {
+		return val, false
+	}
+	return buf.Bytes(), true
+}
+
+func decompress(data []byte) ([]byte, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("Error decompressing cache entry: %w", err)
+	}
+	defer zr.Close()
+
+	val, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, fmt.Errorf("Error decompressing cache entry: %w", err)
+	}
+	return val, nil
+}
+
+// value returns the original bytes of the entry, decompressing them if
+// needed. Entry fields other than decoded never change after insertion, so
+// this is safe to call without the shard lock.
+func (e *CacheEntry) value() ([]byte, error) {
+	if !e.compressed {
+		return e.val, nil
+	}
+	return decompress(e.val)
+}
@@ -0,0 +1,111 @@
+package pokecache
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCompressionRoundTrip(t *testing.T) {
+	cache := NewCache(5*time.Second, WithCompression(64))
+	defer cache.Close()
+
+	val := []byte(strings.Repeat(`{"name":"pikachu","url":"https://pokeapi.co/api/v2/pokemon/25/"}`, 100))
+	cache.Add("key1", val)
+
+	got, ok := cache.Get("key1")
+	if !ok {
+		t.Errorf("expected to find key")
+		return
+	}
+	if !bytes.Equal(got, val) {
+		t.Errorf("expected Get to return the original bytes")
+		return
+	}
+
+	entry, ok := cache.Peek("key1")
+	if !ok || !bytes.Equal(entry.Val, val) {
+		t.Errorf("expected Peek to return the original bytes")
+		return
+	}
+
+	stats := cache.Stats()
+	if stats.RawBytes != len("key1")+len(val) {
+		t.Errorf("expected raw bytes %d, got %d", len("key1")+len(val), stats.RawBytes)
+		return
+	}
+	if stats.Bytes >= stats.RawBytes/4 {
+		t.Errorf("expected repetitive value to compress well, stored %d of %d bytes", stats.Bytes, stats.RawBytes)
+		return
+	}
+}
+
+func TestCompressionBelowThreshold(t *testing.T) {
+	cache := NewCache(5*time.Second, WithCompression(1024))
+	defer cache.Close()
+
+	cache.Add("key1", []byte("short value"))
+
+	stats := cache.Stats()
+	if stats.Bytes != stats.RawBytes {
+		t.Errorf("expected small value to be stored uncompressed, got %+v", stats)
+		return
+	}
+}
+
+func TestCompressionSkipsIncompressibleValues(t *testing.T) {
+	val := make([]byte, 256)
+	for i := range val {
+		val[i] = byte(i*7 + i*i)
+	}
+
+	if _, ok := compress(val); ok {
+		t.Errorf("expected incompressible value to be stored as is")
+		return
+	}
+}
+
+func TestCompressionPersistsToDisk(t *testing.T) {
+	dir := t.TempDir()
+	val := []byte(strings.Repeat("sprite-url ", 500))
+
+	cache, err := NewPersistentCache(5*time.Second, dir, WithCompression(64))
+	if err != nil {
+		t.Errorf("expected no error creating cache, got %v", err)
+		return
+	}
+	defer cache.Close()
+	cache.Add("key1", val)
+
+	// Reopen without compression to check entries are read back either way
+	reopened, err := NewPersistentCache(5*time.Second, dir)
+	if err != nil {
+		t.Errorf("expected no error reopening cache, got %v", err)
+		return
+	}
+	defer reopened.Close()
+
+	got, ok := reopened.Get("key1")
+	if !ok || !bytes.Equal(got, val) {
+		t.Errorf("expected compressed entry to be read back from disk")
+		return
+	}
+	if stats := reopened.Stats(); stats.Bytes >= stats.RawBytes {
+		t.Errorf("expected entry to stay compressed in memory, got %+v", stats)
+		return
+	}
+}
+
+func TestTypedDecodesCompressedEntry(t *testing.T) {
+	cache := NewCache(5*time.Second, WithCompression(16))
+	defer cache.Close()
+
+	cache.Add("key1", []byte(`{"name":"pikachu pikachu pikachu pikachu","count":25}`))
+
+	val, ok := NewTyped[typedTestValue](cache).Get("key1")
+	if !ok || val.Count != 25 {
+		t.Errorf("expected compressed entry to decode, got %+v", val)
+		return
+	}
+}
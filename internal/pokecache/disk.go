@@ -0,0 +1,345 @@
+package pokecache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const diskFileSuffix string = ".json"
+
+// diskEntry is the on-disk representation of a CacheEntry. The key is stored
+// alongside the value so a hash collision can never serve the wrong body.
+type diskEntry struct {
+	Key          string        `json:"key"`
+	CreatedAt    time.Time     `json:"created_at"`
+	TTL          time.Duration `json:"ttl"`
+	Val          []byte        `json:"val"`
+	Compressed   bool          `json:"compressed,omitempty"`
+	RawSize      int           `json:"raw_size,omitempty"`
+	ETag         string        `json:"etag,omitempty"`
+	LastModified string        `json:"last_modified,omitempty"`
+}
+
+// diskHeader is the part of a diskEntry needed to list keys. Decoding into
+// it skips the value instead of decoding it.
+type diskHeader struct {
+	Key       string        `json:"key"`
+	CreatedAt time.Time     `json:"created_at"`
+	TTL       time.Duration `json:"ttl"`
+}
+
+// diskStore keeps one file per cache key inside dir. File modification times
+// are set to the time an entry can be dropped, its expiry plus the grace
+// period stale entries are kept for, so files can be reaped without reading
+// them.
+type diskStore struct {
+	dir   string
+	grace time.Duration
+}
+
+func newDiskStore(dir string) (*diskStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("Error creating cache directory: %w", err)
+	}
+	return &diskStore{dir: dir}, nil
+}
+
+// DefaultDir returns the directory used for the persistent cache, placed under
+// the user cache dir (e.g. ~/.cache/pokedex on Linux).
+func DefaultDir() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("Error locating user cache dir: %w", err)
+	}
+	return filepath.Join(base, "pokedex"), nil
+}
+
+func (d *diskStore) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:])+diskFileSuffix)
+}
+
+func (d *diskStore) load(key string) (*CacheEntry, bool) {
+	entry, err := readEntryFile(d.path(key))
+	if err != nil || entry.key != key {
+		return nil, false
+	}
+	return entry, true
+}
+
+func readEntryFile(path string) (*CacheEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entry diskEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, err
+	}
+
+	if !entry.Compressed {
+		entry.RawSize = len(entry.Val)
+	}
+
+	return &CacheEntry{
+		key:          entry.Key,
+		createdAt:    entry.CreatedAt,
+		ttl:          entry.TTL,
+		val:          entry.Val,
+		compressed:   entry.Compressed,
+		rawSize:      entry.RawSize,
+		etag:         entry.ETag,
+		lastModified: entry.LastModified,
+	}, nil
+}
+
+// eachHeader calls fn with the header of every readable entry file, without
+// decoding values. Unreadable files are skipped.
+func (d *diskStore) eachHeader(fn func(header diskHeader, path string)) error {
+	files, err := os.ReadDir(d.dir)
+	if err != nil {
+		return fmt.Errorf("Error reading cache directory: %w", err)
+	}
+
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), diskFileSuffix) {
+			continue
+		}
+		path := filepath.Join(d.dir, file.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var header diskHeader
+		if err := json.Unmarshal(data, &header); err != nil {
+			continue
+		}
+		fn(header, path)
+	}
+	return nil
+}
+
+// each calls fn for every readable entry file. Unreadable files are skipped.
+func (d *diskStore) each(fn func(*CacheEntry)) error {
+	files, err := os.ReadDir(d.dir)
+	if err != nil {
+		return fmt.Errorf("Error reading cache directory: %w", err)
+	}
+
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), diskFileSuffix) {
+			continue
+		}
+		entry, err := readEntryFile(filepath.Join(d.dir, file.Name()))
+		if err != nil {
+			continue
+		}
+		fn(entry)
+	}
+	return nil
+}
+
+// save writes the entry to a temporary file and renames it into place so a
+// crash mid-write never leaves a truncated entry behind.
+func (d *diskStore) save(entry *CacheEntry) error {
+	data, err := json.Marshal(diskEntry{
+		Key:          entry.key,
+		CreatedAt:    entry.createdAt,
+		TTL:          entry.ttl,
+		Val:          entry.val,
+		Compressed:   entry.compressed,
+		RawSize:      entry.rawSize,
+		ETag:         entry.etag,
+		LastModified: entry.lastModified,
+	})
+	if err != nil {
+		return fmt.Errorf("Error encoding cache entry: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(d.dir, "entry-*.tmp")
+	if err != nil {
+		return fmt.Errorf("Error creating cache file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("Error writing cache file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("Error writing cache file: %w", err)
+	}
+
+	target := d.path(entry.key)
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		return fmt.Errorf("Error storing cache file: %w", err)
+	}
+	dropAt := entry.createdAt.Add(entry.ttl + d.grace)
+	return os.Chtimes(target, dropAt, dropAt)
+}
+
+func (d *diskStore) remove(key string) {
+	os.Remove(d.path(key))
+}
+
+// clear deletes the entry files of the keys starting with prefix, or every
+// entry file for an empty prefix.
+func (d *diskStore) clear(prefix string) error {
+	if prefix != "" {
+		var removeErr error
+		err := d.eachHeader(func(header diskHeader, path string) {
+			if !strings.HasPrefix(header.Key, prefix) {
+				return
+			}
+			if err := os.Remove(path); err != nil && removeErr == nil {
+				removeErr = fmt.Errorf("Error removing cache file: %w", err)
+			}
+		})
+		if err != nil {
+			return err
+		}
+		return removeErr
+	}
+
+	files, err := os.ReadDir(d.dir)
+	if err != nil {
+		return fmt.Errorf("Error reading cache directory: %w", err)
+	}
+
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), diskFileSuffix) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(d.dir, file.Name())); err != nil {
+			return fmt.Errorf("Error removing cache file: %w", err)
+		}
+	}
+	return nil
+}
+
+// reap deletes every entry file that has outlived its expiry and grace period.
+func (d *diskStore) reap(now time.Time) {
+	files, err := os.ReadDir(d.dir)
+	if err != nil {
+		return
+	}
+
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), diskFileSuffix) {
+			continue
+		}
+		info, err := file.Info()
+		if err != nil {
+			continue
+		}
+		if now.After(info.ModTime()) {
+			os.Remove(filepath.Join(d.dir, file.Name()))
+		}
+	}
+}
+
+// DiskStore is a standalone Store keeping every entry in its own file, for
+// use on its own or as the back tier of a TieredStore. Expired entries are
+// dropped when they are read.
+type DiskStore struct {
+	disk *diskStore
+	ttl  time.Duration
+}
+
+// NewDiskStore returns a DiskStore in dir whose entries live for ttl unless
+// added with their own TTL.
+func NewDiskStore(dir string, ttl time.Duration) (*DiskStore, error) {
+	disk, err := newDiskStore(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &DiskStore{disk: disk, ttl: ttl}, nil
+}
+
+func (d *DiskStore) Get(key string) ([]byte, bool) {
+	entry, ok := d.Peek(key)
+	if !ok || entry.Stale() {
+		return nil, false
+	}
+	return entry.Val, true
+}
+
+// Peek returns the entry for key until it is reaped, even if it is stale.
+func (d *DiskStore) Peek(key string) (Entry, bool) {
+	entry, ok := d.disk.load(key)
+	if !ok {
+		return Entry{}, false
+	}
+	if entry.retired(time.Now(), d.disk.grace) {
+		d.disk.remove(key)
+		return Entry{}, false
+	}
+	val, err := entry.value()
+	if err != nil {
+		return Entry{}, false
+	}
+	return Entry{
+		Val:          val,
+		CreatedAt:    entry.createdAt,
+		TTL:          entry.ttl,
+		ETag:         entry.etag,
+		LastModified: entry.lastModified,
+	}, true
+}
+
+func (d *DiskStore) Add(key string, val []byte) error {
+	return d.AddEntry(key, Entry{Val: val})
+}
+
+func (d *DiskStore) AddEntry(key string, e Entry) error {
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = time.Now()
+	}
+	if e.TTL <= 0 {
+		e.TTL = d.ttl
+	}
+	return d.disk.save(&CacheEntry{
+		key:          key,
+		createdAt:    e.CreatedAt,
+		ttl:          e.TTL,
+		val:          e.Val,
+		rawSize:      len(e.Val),
+		etag:         e.ETag,
+		lastModified: e.LastModified,
+	})
+}
+
+func (d *DiskStore) Delete(key string) bool {
+	return os.Remove(d.disk.path(key)) == nil
+}
+
+func (d *DiskStore) Range(fn func(key string, val []byte) bool) {
+	now := time.Now()
+	stopped := false
+	d.disk.each(func(entry *CacheEntry) {
+		if stopped || entry.expired(now) {
+			return
+		}
+		val, err := entry.value()
+		if err != nil {
+			return
+		}
+		stopped = !fn(entry.key, val)
+	})
+}
+
+// Reap deletes the files of expired entries.
+func (d *DiskStore) Reap() {
+	d.disk.reap(time.Now())
+}
+
+func (d *DiskStore) Close() error {
+	return nil
+}
@@ -0,0 +1,261 @@
+package pokecache
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPersistentCacheSurvivesRestart(t *testing.T) {
+	dir := t.TempDir()
+
+	cache, err := NewPersistentCache(5*time.Second, dir)
+	if err != nil {
+		t.Errorf("expected no error creating cache, got %v", err)
+		return
+	}
+	defer cache.Close()
+	cache.Add("https://example.com", []byte("testdata"))
+
+	reopened, err := NewPersistentCache(5*time.Second, dir)
+	if err != nil {
+		t.Errorf("expected no error reopening cache, got %v", err)
+		return
+	}
+	defer reopened.Close()
+
+	val, ok := reopened.Get("https://example.com")
+	if !ok {
+		t.Errorf("expected to find key after restart")
+		return
+	}
+	if string(val) != "testdata" {
+		t.Errorf("expected testdata, got %s", string(val))
+		return
+	}
+}
+
+func TestPersistentCacheExpiredOnDisk(t *testing.T) {
+	dir := t.TempDir()
+	const ttl = 50 * time.Millisecond
+
+	cache, err := NewPersistentCache(time.Hour, dir)
+	if err != nil {
+		t.Errorf("expected no error creating cache, got %v", err)
+		return
+	}
+	defer cache.Close()
+	cache.AddWithTTL("https://example.com", []byte("testdata"), ttl)
+
+	time.Sleep(ttl + 10*time.Millisecond)
+
+	reopened, err := NewPersistentCache(time.Hour, dir)
+	if err != nil {
+		t.Errorf("expected no error reopening cache, got %v", err)
+		return
+	}
+	defer reopened.Close()
+
+	if _, ok := reopened.Get("https://example.com"); ok {
+		t.Errorf("expected expired entry not to be loaded from disk")
+		return
+	}
+}
+
+func TestPersistentCacheKeepsEntryTTL(t *testing.T) {
+	dir := t.TempDir()
+	const interval = 10 * time.Millisecond
+
+	cache, err := NewPersistentCache(interval, dir)
+	if err != nil {
+		t.Errorf("expected no error creating cache, got %v", err)
+		return
+	}
+	defer cache.Close()
+	cache.AddWithTTL("https://example.com", []byte("testdata"), time.Hour)
+
+	// Give the reaper a few ticks to run over the directory
+	time.Sleep(interval * 5)
+
+	reopened, err := NewPersistentCache(interval, dir)
+	if err != nil {
+		t.Errorf("expected no error reopening cache, got %v", err)
+		return
+	}
+	defer reopened.Close()
+
+	if _, ok := reopened.Get("https://example.com"); !ok {
+		t.Errorf("expected long-lived entry to survive reaping and restart")
+		return
+	}
+}
+
+func TestPersistentCacheReapRemovesFiles(t *testing.T) {
+	dir := t.TempDir()
+	const interval = 10 * time.Millisecond
+
+	cache, err := NewPersistentCache(interval, dir)
+	if err != nil {
+		t.Errorf("expected no error creating cache, got %v", err)
+		return
+	}
+	defer cache.Close()
+	cache.Add("https://example.com", []byte("testdata"))
+
+	time.Sleep(interval * 5)
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		t.Errorf("expected no error reading dir, got %v", err)
+		return
+	}
+	if len(files) != 0 {
+		t.Errorf("expected reaper to remove expired files, found %d", len(files))
+		return
+	}
+}
+
+func TestDiskStoreIgnoresMismatchedKey(t *testing.T) {
+	disk, err := newDiskStore(t.TempDir())
+	if err != nil {
+		t.Errorf("expected no error creating store, got %v", err)
+		return
+	}
+
+	disk.save(&CacheEntry{key: "key1", createdAt: time.Now(), ttl: time.Hour, val: []byte("value1")})
+	os.Rename(disk.path("key1"), disk.path("key2"))
+
+	if _, ok := disk.load("key2"); ok {
+		t.Errorf("expected entry stored under another key to be ignored")
+		return
+	}
+}
+
+func TestPersistentCacheDeleteAndClearRemoveFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	cache, err := NewPersistentCache(5*time.Second, dir)
+	if err != nil {
+		t.Errorf("expected no error creating cache, got %v", err)
+		return
+	}
+	defer cache.Close()
+
+	cache.Add("key1", []byte("value1"))
+	cache.Add("key2", []byte("value2"))
+	cache.Add("key3", []byte("value3"))
+
+	cache.Delete("key1")
+	if files, _ := os.ReadDir(dir); len(files) != 2 {
+		t.Errorf("expected 2 files after Delete, found %d", len(files))
+		return
+	}
+
+	if err := cache.Clear(); err != nil {
+		t.Errorf("expected no error clearing cache, got %v", err)
+		return
+	}
+	if files, _ := os.ReadDir(dir); len(files) != 0 {
+		t.Errorf("expected no files after Clear, found %d", len(files))
+		return
+	}
+}
+
+func TestPersistentCacheKeepsValidators(t *testing.T) {
+	dir := t.TempDir()
+
+	cache, err := NewPersistentCache(5*time.Second, dir)
+	if err != nil {
+		t.Errorf("expected no error creating cache, got %v", err)
+		return
+	}
+	defer cache.Close()
+	cache.AddEntry("key1", Entry{Val: []byte("value1"), ETag: `"abc"`, LastModified: "yesterday"})
+
+	reopened, err := NewPersistentCache(5*time.Second, dir)
+	if err != nil {
+		t.Errorf("expected no error reopening cache, got %v", err)
+		return
+	}
+	defer reopened.Close()
+
+	entry, ok := reopened.Peek("key1")
+	if !ok {
+		t.Errorf("expected to find key after restart")
+		return
+	}
+	if entry.ETag != `"abc"` || entry.LastModified != "yesterday" {
+		t.Errorf("expected validators to be persisted, got %+v", entry)
+		return
+	}
+}
+
+func TestPersistentCacheConcurrentReloads(t *testing.T) {
+	dir := t.TempDir()
+
+	// A tiny budget keeps evicting entries so readers reload them from disk
+	cache, err := NewPersistentCache(5*time.Second, dir, WithMaxEntries(2))
+	if err != nil {
+		t.Errorf("expected no error creating cache, got %v", err)
+		return
+	}
+	defer cache.Close()
+
+	const workers = 8
+	const versions = 50
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			key := fmt.Sprintf("key-%d", w)
+			for v := 0; v < versions; v++ {
+				cache.Add(key, []byte(fmt.Sprintf("value-%d", v)))
+				cache.Get(key)
+				cache.Get(fmt.Sprintf("key-%d", (w+1)%workers))
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	want := fmt.Sprintf("value-%d", versions-1)
+	for w := 0; w < workers; w++ {
+		key := fmt.Sprintf("key-%d", w)
+		if val, ok := cache.Get(key); !ok || string(val) != want {
+			t.Errorf("expected %s to hold %s, got %q (found: %v)", key, want, val, ok)
+			return
+		}
+	}
+}
+
+func TestPersistentCacheKeysIncludeDiskOnlyEntries(t *testing.T) {
+	dir := t.TempDir()
+
+	writer, err := NewPersistentCache(5*time.Second, dir)
+	if err != nil {
+		t.Errorf("expected no error creating cache, got %v", err)
+		return
+	}
+	writer.Add("key2", []byte("value2"))
+	writer.AddWithTTL("expired", []byte("value"), time.Nanosecond)
+	writer.Close()
+
+	reopened, err := NewPersistentCache(5*time.Second, dir, WithStaleWindow(time.Hour))
+	if err != nil {
+		t.Errorf("expected no error reopening cache, got %v", err)
+		return
+	}
+	defer reopened.Close()
+	reopened.Add("key1", []byte("value1"))
+
+	if keys := reopened.Keys(); len(keys) != 2 || keys[0] != "key1" || keys[1] != "key2" {
+		t.Errorf("expected the fresh keys in memory and on disk, got %v", keys)
+		return
+	}
+	if stats := reopened.Stats(); stats.Entries != 1 {
+		t.Errorf("expected listing keys to leave disk entries unloaded, got %+v", stats)
+		return
+	}
+}
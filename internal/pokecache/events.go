@@ -0,0 +1,102 @@
+package pokecache
+
+// EventKind identifies what happened to a cache entry.
+type EventKind int
+
+const (
+	// EventAdd is sent when an entry is stored.
+	EventAdd EventKind = iota
+	// EventHit is sent when Get finds a fresh entry.
+	EventHit
+	// EventMiss is sent when Get finds nothing fresh.
+	EventMiss
+	// EventExpire is sent when an entry is dropped for outliving its TTL
+	// and the stale window.
+	EventExpire
+	// EventEvict is sent when an entry is dropped to stay within the size
+	// limits.
+	EventEvict
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case EventAdd:
+		return "add"
+	case EventHit:
+		return "hit"
+	case EventMiss:
+		return "miss"
+	case EventExpire:
+		return "expire"
+	case EventEvict:
+		return "evict"
+	default:
+		return "unknown"
+	}
+}
+
+// Event describes a single cache operation. Size is the number of bytes the
+// entry takes in memory, or zero for a miss.
+type Event struct {
+	Kind EventKind
+	Key  string
+	Size int
+}
+
+type subscription struct {
+	fn func(Event)
+}
+
+// Subscribe registers fn to be called for every cache event and returns a
+// function that unregisters it. fn is called after the cache lock is
+// released, so it may use the cache, but it runs on the goroutine that
+// caused the event and should return quickly. Events from different
+// goroutines may be delivered concurrently and out of order.
+func (c *Cache) Subscribe(fn func(Event)) (unsubscribe func()) {
+	sub := &subscription{fn: fn}
+
+	c.subsMu.Lock()
+	c.subs = append(c.subs, sub)
+	c.observed.Store(true)
+	c.subsMu.Unlock()
+
+	return func() {
+		c.subsMu.Lock()
+		defer c.subsMu.Unlock()
+		for i, s := range c.subs {
+			if s == sub {
+				c.subs = append(c.subs[:i:i], c.subs[i+1:]...)
+				break
+			}
+		}
+		c.observed.Store(len(c.subs) > 0)
+	}
+}
+
+// unlock releases s.mu and delivers the events recorded while it was held.
+func (c *Cache) unlock(s *shard) {
+	events := s.pending
+	s.pending = nil
+	s.mu.Unlock()
+
+	if len(events) == 0 {
+		return
+	}
+	c.subsMu.Lock()
+	subs := c.subs
+	c.subsMu.Unlock()
+
+	for _, event := range events {
+		for _, sub := range subs {
+			sub.fn(event)
+		}
+	}
+}
+
+// record queues an event for delivery once s.mu is released, if anyone is
+// subscribed. The caller must hold s.mu.
+func (s *shard) record(kind EventKind, key string, size int) {
+	if s.observed.Load() {
+		s.pending = append(s.pending, Event{Kind: kind, Key: key, Size: size})
+	}
+}
@@ -0,0 +1,146 @@
+package pokecache
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// eventRecorder collects events delivered to a subscriber.
+type eventRecorder struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (r *eventRecorder) record(e Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+}
+
+func (r *eventRecorder) kinds() []EventKind {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	kinds := make([]EventKind, len(r.events))
+	for i, e := range r.events {
+		kinds[i] = e.Kind
+	}
+	return kinds
+}
+
+func TestSubscribeAddHitMiss(t *testing.T) {
+	cache := NewCache(5 * time.Second)
+	defer cache.Close()
+
+	var recorder eventRecorder
+	cache.Subscribe(recorder.record)
+
+	cache.Add("key1", []byte("value1"))
+	cache.Get("key1")
+	cache.Get("missing")
+	cache.Peek("key1")
+
+	expected := []EventKind{EventAdd, EventHit, EventMiss}
+	kinds := recorder.kinds()
+	if len(kinds) != len(expected) {
+		t.Errorf("expected events %v, got %v", expected, kinds)
+		return
+	}
+	for i := range expected {
+		if kinds[i] != expected[i] {
+			t.Errorf("expected events %v, got %v", expected, kinds)
+			return
+		}
+	}
+
+	first := recorder.events[0]
+	if first.Key != "key1" || first.Size == 0 {
+		t.Errorf("expected add event for key1 with a size, got %+v", first)
+		return
+	}
+}
+
+func TestSubscribeEvict(t *testing.T) {
+	cache := NewCache(5*time.Second, WithShards(1), WithMaxEntries(1))
+	defer cache.Close()
+
+	var recorder eventRecorder
+	cache.Subscribe(recorder.record)
+
+	cache.Add("key1", []byte("value1"))
+	cache.Add("key2", []byte("value2"))
+
+	kinds := recorder.kinds()
+	if len(kinds) != 3 || kinds[1] != EventEvict || recorder.events[1].Key != "key1" {
+		t.Errorf("expected key1 to be evicted by the second add, got %+v", recorder.events)
+		return
+	}
+}
+
+func TestSubscribeExpire(t *testing.T) {
+	const interval = 10 * time.Millisecond
+	cache := NewCache(interval, WithShards(1))
+	defer cache.Close()
+
+	var recorder eventRecorder
+	cache.Subscribe(recorder.record)
+
+	cache.Add("key1", []byte("value1"))
+	time.Sleep(interval * 5)
+
+	for _, kind := range recorder.kinds() {
+		if kind == EventExpire {
+			return
+		}
+	}
+	t.Errorf("expected an expire event, got %v", recorder.kinds())
+}
+
+func TestUnsubscribe(t *testing.T) {
+	cache := NewCache(5 * time.Second)
+	defer cache.Close()
+
+	var first, second eventRecorder
+	unsubscribe := cache.Subscribe(first.record)
+	cache.Subscribe(second.record)
+
+	cache.Add("key1", []byte("value1"))
+	unsubscribe()
+	cache.Add("key2", []byte("value2"))
+
+	if len(first.kinds()) != 1 {
+		t.Errorf("expected 1 event before unsubscribing, got %d", len(first.kinds()))
+		return
+	}
+	if len(second.kinds()) != 2 {
+		t.Errorf("expected remaining subscriber to get 2 events, got %d", len(second.kinds()))
+		return
+	}
+}
+
+func TestSubscriberMayUseCache(t *testing.T) {
+	cache := NewCache(5 * time.Second)
+	defer cache.Close()
+
+	done := make(chan struct{})
+	cache.Subscribe(func(e Event) {
+		if e.Kind == EventAdd {
+			cache.Peek(e.Key)
+			close(done)
+		}
+	})
+
+	cache.Add("key1", []byte("value1"))
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Errorf("expected subscriber to use the cache without deadlocking")
+	}
+}
+
+func TestEventKindString(t *testing.T) {
+	if EventEvict.String() != "evict" || EventKind(99).String() != "unknown" {
+		t.Errorf("unexpected event kind names %q and %q", EventEvict, EventKind(99))
+	}
+}
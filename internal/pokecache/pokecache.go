@@ -1,70 +1,716 @@
 package pokecache
 
 import (
+	"context"
+	"errors"
+	"hash/maphash"
+	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
+// ErrClosed is returned when writing to a cache after Close.
+var ErrClosed = errors.New("cache is closed")
+
 type CacheEntry struct {
+	key       string
 	createdAt time.Time
-	val       []byte
+	ttl       time.Duration
+	// val holds the stored bytes, gzipped when compressed is set. rawSize
+	// is the length of the original value.
+	val          []byte
+	compressed   bool
+	rawSize      int
+	etag         string
+	lastModified string
+	// decoded memoizes the value decoded from val by a Typed view and
+	// decodedSize estimates the memory it holds, which is counted against
+	// the byte budget.
+	decoded     any
+	decodedSize int
+	// used is the budget clock reading of the last time the entry was
+	// added or looked up.
+	used uint64
+	// dropped is set once the entry was replaced, deleted or cleared, so a
+	// pending disk write of it is skipped.
+	dropped bool
+}
+
+func (e *CacheEntry) expired(now time.Time) bool {
+	return now.Sub(e.createdAt) > e.ttl
+}
+
+// retired reports whether an expired entry has also outlived the stale
+// window and can be dropped.
+func (e *CacheEntry) retired(now time.Time, staleWindow time.Duration) bool {
+	return now.Sub(e.createdAt) > e.ttl+staleWindow
+}
+
+// Entry is a cached value together with the HTTP validators needed to
+// revalidate it once it goes stale.
+type Entry struct {
+	Val          []byte
+	CreatedAt    time.Time
+	TTL          time.Duration
+	ETag         string
+	LastModified string
+}
+
+// Stale reports whether the entry has outlived its TTL. An entry without a
+// TTL is never stale.
+func (e Entry) Stale() bool {
+	return e.TTL > 0 && time.Since(e.CreatedAt) > e.TTL
+}
+
+// size is the number of bytes the key and stored value take up.
+func (e *CacheEntry) size() int {
+	return len(e.key) + len(e.val)
+}
+
+// footprint is the number of bytes an entry counts against the cache budget,
+// its size plus its memoized decoded value.
+func (e *CacheEntry) footprint() int {
+	return e.size() + e.decodedSize
+}
+
+// Stats is a point-in-time snapshot of the cache counters. Entries, Bytes,
+// RawBytes, DecodedBytes and OldestAge describe the in-memory entries only.
+// Bytes is what the entries take up as stored, RawBytes what they would take
+// up without compression and DecodedBytes the estimated size of the values
+// memoized by Typed views. The byte budget covers Bytes plus DecodedBytes.
+// Shards are read one after another, so the snapshot is not atomic under
+// concurrent writes.
+type Stats struct {
+	Entries      int
+	Bytes        int
+	RawBytes     int
+	DecodedBytes int
+	Hits         uint64
+	Misses       uint64
+	Evictions    uint64
+	Expirations  uint64
+	OldestAge    time.Duration
+}
+
+// TTLPolicy picks the time to live for a key. Returning zero falls back to
+// the cache interval.
+type TTLPolicy func(key string) time.Duration
+
+// Option configures optional cache behaviour.
+type Option func(*Cache)
+
+// WithTTLPolicy sets the policy used by Add to pick a time to live per key,
+// e.g. to keep immutable resources much longer than paginated listings.
+func WithTTLPolicy(policy TTLPolicy) Option {
+	return func(c *Cache) {
+		c.policy = policy
+	}
+}
+
+// WithStaleWindow keeps expired entries for d after their TTL so Peek can
+// serve them while they are revalidated. Get still treats them as misses.
+func WithStaleWindow(d time.Duration) Option {
+	return func(c *Cache) {
+		c.staleWindow = d
+	}
+}
+
+// WithMaxBytes bounds the total size of keys and values held in memory,
+// including the decoded values memoized by Typed views. The limit applies to
+// the cache as a whole: once it is exceeded the least recently used entries
+// are evicted, whichever shard holds them.
+func WithMaxBytes(n int) Option {
+	return func(c *Cache) {
+		c.budget.maxBytes = n
+	}
+}
+
+// WithMaxEntries bounds the number of entries held in memory across the
+// whole cache, evicting like WithMaxBytes.
+func WithMaxEntries(n int) Option {
+	return func(c *Cache) {
+		c.budget.maxEntries = n
+	}
+}
+
+// WithShards sets the number of independently locked shards. More shards
+// mean less contention between concurrent readers; size limits and LRU order
+// apply across the whole cache either way.
+func WithShards(n int) Option {
+	return func(c *Cache) {
+		if n > 0 {
+			c.shardCount = n
+		}
+	}
 }
 
 type Cache struct {
-	cacheEntries map[string]CacheEntry
-	mu           sync.Mutex
+	shards     []*shard
+	shardCount int
+	seed       maphash.Seed
+	budget     budget
+	// evictMu serializes enforceBudget so concurrent writers do not evict
+	// more than needed.
+	evictMu     sync.Mutex
+	interval    time.Duration
+	policy      TTLPolicy
+	staleWindow time.Duration
+	compressAt  int
+	disk        *diskStore
+	closed      atomic.Bool
+	done        chan struct{}
+	stopped     chan struct{}
+	closeOnce   sync.Once
+	// observed is set while subs is not empty.
+	observed atomic.Bool
+	subsMu   sync.Mutex
+	subs     []*subscription
 }
 
-func (c *Cache) reapLoop(interval time.Duration) {
-	ticker := time.NewTicker(interval)
+// reapLoop reaps one shard per tick so each shard is visited once per
+// interval and readers of other shards are never blocked by the scan.
+func (c *Cache) reapLoop(ctx context.Context, interval time.Duration) {
+	tick := interval / time.Duration(len(c.shards))
+	if tick <= 0 {
+		tick = interval
+	}
+	ticker := time.NewTicker(tick)
 	defer ticker.Stop()
+	defer close(c.stopped)
 
+	next := 0
 	for {
 		select {
 		case <-ticker.C:
-			c.mu.Lock()
 			currentTime := time.Now()
+			s := c.shards[next]
+			s.mu.Lock()
+			s.reap(currentTime, c.staleWindow)
+			c.unlock(s)
 
-			for key, entry := range c.cacheEntries {
-				age := currentTime.Sub(entry.createdAt)
-
-				if age > interval {
-					delete(c.cacheEntries, key)
-				}
+			next = (next + 1) % len(c.shards)
+			if next == 0 && c.disk != nil {
+				c.disk.reap(currentTime)
 			}
-
-			c.mu.Unlock()
+		case <-ctx.Done():
+			c.shutdown()
+			return
+		case <-c.done:
+			return
 		}
 	}
 
 }
 
-func NewCache(interval time.Duration) *Cache {
+func newCache(ctx context.Context, interval time.Duration, disk *diskStore, opts []Option) *Cache {
 	cache := &Cache{
-		cacheEntries: make(map[string]CacheEntry),
-		mu:           sync.Mutex{},
+		shardCount: DEFAULT_SHARDS,
+		seed:       maphash.MakeSeed(),
+		interval:   interval,
+		disk:       disk,
+		done:       make(chan struct{}),
+		stopped:    make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(cache)
+	}
+	cache.shards = make([]*shard, cache.shardCount)
+	for i := range cache.shards {
+		cache.shards[i] = newShard(&cache.budget, &cache.observed)
+	}
+	if disk != nil {
+		disk.grace = cache.staleWindow
 	}
-	go cache.reapLoop(interval)
+	go cache.reapLoop(ctx, interval)
 	return cache
 }
 
-func (c *Cache) Add(key string, val []byte) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	c.cacheEntries[key] = CacheEntry{
-		createdAt: time.Now(),
-		val:       val,
+func NewCache(interval time.Duration, opts ...Option) *Cache {
+	return newCache(context.Background(), interval, nil, opts)
+}
+
+// NewCacheWithContext returns a cache that closes itself once ctx is done.
+func NewCacheWithContext(ctx context.Context, interval time.Duration, opts ...Option) *Cache {
+	return newCache(ctx, interval, nil, opts)
+}
+
+// NewPersistentCache returns a cache that also writes every entry to dir, so
+// entries survive restarts. Entries on disk are loaded lazily by Get and
+// expire after the same TTL as in-memory entries. Size limits only apply
+// to the in-memory entries; evicted entries can be reloaded from disk.
+func NewPersistentCache(interval time.Duration, dir string, opts ...Option) (*Cache, error) {
+	disk, err := newDiskStore(dir)
+	if err != nil {
+		return nil, err
 	}
+	return newCache(context.Background(), interval, disk, opts), nil
+}
 
+// Close stops the reaper goroutine and drops the in-memory entries. Entries
+// are written to disk as they are added, so nothing is lost for a persistent
+// cache. Close waits for the reaper to exit and is safe to call more than once.
+func (c *Cache) Close() error {
+	c.shutdown()
+	<-c.stopped
+	return nil
 }
 
+// Err returns ErrClosed once the cache is closed, by Close or by the
+// cancellation of its context, and nil before.
+func (c *Cache) Err() error {
+	if c.closed.Load() {
+		return ErrClosed
+	}
+	return nil
+}
+
+func (c *Cache) shutdown() {
+	c.closeOnce.Do(func() {
+		c.closed.Store(true)
+		for _, s := range c.shards {
+			s.mu.Lock()
+			s.reset()
+			s.mu.Unlock()
+		}
+		close(c.done)
+	})
+}
+
+func (c *Cache) shardFor(key string) *shard {
+	return c.shards[maphash.String(c.seed, key)%uint64(len(c.shards))]
+}
+
+func (c *Cache) ttlFor(key string) time.Duration {
+	if c.policy != nil {
+		if ttl := c.policy(key); ttl > 0 {
+			return ttl
+		}
+	}
+	return c.interval
+}
+
+// Add stores val under key with the TTL chosen by the cache policy. It
+// returns ErrClosed once the cache is closed, or the write error of a
+// persistent cache, in which case the entry is still served from memory.
+func (c *Cache) Add(key string, val []byte) error {
+	return c.AddWithTTL(key, val, c.ttlFor(key))
+}
+
+// AddWithTTL stores val under key for ttl, overriding the cache policy. A
+// zero ttl uses the cache interval.
+func (c *Cache) AddWithTTL(key string, val []byte, ttl time.Duration) error {
+	return c.AddEntry(key, Entry{Val: val, TTL: ttl})
+}
+
+// AddEntry stores e under key. A zero CreatedAt means now and a zero TTL is
+// picked by the cache policy.
+func (c *Cache) AddEntry(key string, e Entry) error {
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = time.Now()
+	}
+	if e.TTL <= 0 {
+		e.TTL = c.ttlFor(key)
+	}
+	entry := &CacheEntry{
+		key:          key,
+		createdAt:    e.CreatedAt,
+		ttl:          e.TTL,
+		val:          e.Val,
+		rawSize:      len(e.Val),
+		etag:         e.ETag,
+		lastModified: e.LastModified,
+	}
+	if c.compressAt > 0 && len(e.Val) >= c.compressAt {
+		entry.val, entry.compressed = compress(e.Val)
+	}
+
+	if c.closed.Load() {
+		return ErrClosed
+	}
+	c.makeRoom(entry)
+
+	s := c.shardFor(key)
+	s.mu.Lock()
+	if c.closed.Load() {
+		c.unlock(s)
+		return ErrClosed
+	}
+	s.insert(entry)
+	s.record(EventAdd, key, entry.size())
+	c.unlock(s)
+	c.enforceBudget()
+
+	if c.disk != nil {
+		return c.save(s, entry)
+	}
+	return nil
+}
+
+// save writes entry to disk without holding s.mu, unless it was replaced or
+// deleted in the meantime, in which case a newer write supersedes it.
+func (c *Cache) save(s *shard, entry *CacheEntry) error {
+	s.diskMu.Lock()
+	defer s.diskMu.Unlock()
+	s.mu.Lock()
+	dropped := entry.dropped
+	s.mu.Unlock()
+	if dropped {
+		return nil
+	}
+	return c.disk.save(entry)
+}
+
+// Get returns the value stored under key. A closed cache never reports a hit;
+// use Err to tell a closed cache from a miss.
 func (c *Cache) Get(key string) ([]byte, bool) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	entry, ok := c.cacheEntries[key]
+	entry, _, ok := c.getEntry(key)
 	if !ok {
 		return nil, false
 	}
+	val, err := entry.value()
+	if err != nil {
+		return nil, false
+	}
+	return val, true
+}
+
+// getEntry is Get returning the fresh entry itself and its memoized decoded
+// value, which may only be read under the shard lock.
+func (c *Cache) getEntry(key string) (*CacheEntry, any, bool) {
+	if c.closed.Load() {
+		return nil, nil, false
+	}
+	s := c.shardFor(key)
+	s.mu.Lock()
+	defer c.enforceBudget()
+	defer c.unlock(s)
+	now := time.Now()
+	entry, ok := c.lookup(s, key, now)
+	if !ok || entry.expired(now) {
+		s.misses++
+		s.record(EventMiss, key, 0)
+		return nil, nil, false
+	}
+	s.hits++
+	s.record(EventHit, key, entry.size())
+	return entry, entry.decoded, true
+}
+
+// setDecoded memoizes v on entry if entry is still the one cached under key,
+// charging its estimated size to the byte budget. A value that would not fit
+// in the budget together with its entry is not memoized.
+func (c *Cache) setDecoded(key string, entry *CacheEntry, v any) {
+	size := decodedSize(v)
+	if c.budget.maxBytes > 0 && entry.size()+size > c.budget.maxBytes {
+		return
+	}
+
+	s := c.shardFor(key)
+	s.mu.Lock()
+	if elem, ok := s.entries[key]; ok && elem.Value.(*CacheEntry) == entry {
+		s.setDecoded(entry, v, size)
+	}
+	s.mu.Unlock()
+	c.enforceBudget()
+}
 
-	return entry.val, true
+// Peek returns the entry stored under key even if it is stale, as long as it
+// is still within the stale window. It does not count as a hit or miss.
+func (c *Cache) Peek(key string) (Entry, bool) {
+	if c.closed.Load() {
+		return Entry{}, false
+	}
+	s := c.shardFor(key)
+	s.mu.Lock()
+	defer c.enforceBudget()
+	defer c.unlock(s)
+	entry, ok := c.lookup(s, key, time.Now())
+	if !ok {
+		return Entry{}, false
+	}
+	val, err := entry.value()
+	if err != nil {
+		return Entry{}, false
+	}
+	return Entry{
+		Val:          val,
+		CreatedAt:    entry.createdAt,
+		TTL:          entry.ttl,
+		ETag:         entry.etag,
+		LastModified: entry.lastModified,
+	}, true
+}
+
+// lookup finds an entry in shard s or on disk, dropping it from memory once
+// it has outlived the stale window. The returned entry may be expired. The
+// caller must hold s.mu, which is released while the disk is read.
+func (c *Cache) lookup(s *shard, key string, now time.Time) (*CacheEntry, bool) {
+	if entry, ok := c.lookupMemory(s, key, now); ok || c.disk == nil {
+		return entry, ok
+	}
+
+	c.unlock(s)
+	s.diskMu.Lock()
+	entry, ok := c.disk.load(key)
+	s.mu.Lock()
+	s.diskMu.Unlock()
+
+	// Another reader may have loaded the entry while the lock was released
+	if cached, found := c.lookupMemory(s, key, now); found {
+		return cached, true
+	}
+	if !ok {
+		return nil, false
+	}
+	if entry.retired(now, c.staleWindow) {
+		// The file is left for the disk reaper
+		s.expirations++
+		s.record(EventExpire, key, entry.size())
+		return nil, false
+	}
+	s.insert(entry)
+
+	return entry, true
+}
+
+// lookupMemory finds an entry in shard s, dropping it once it has outlived
+// the stale window. The caller must hold s.mu.
+func (c *Cache) lookupMemory(s *shard, key string, now time.Time) (*CacheEntry, bool) {
+	elem, ok := s.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*CacheEntry)
+	if entry.retired(now, c.staleWindow) {
+		s.removeElement(elem)
+		s.expirations++
+		s.record(EventExpire, key, entry.size())
+		return nil, false
+	}
+	s.touch(elem)
+	return entry, true
+}
+
+// enforceBudget evicts the least recently used entries of the whole cache
+// until it is back within its limits. The caller must not hold a shard lock.
+func (c *Cache) enforceBudget() {
+	c.evictFor(0, 0)
+}
+
+// makeRoom evicts the least recently used entries of the whole cache until
+// entry fits, so evictions are reported before the add that caused them.
+// Entries larger than the byte budget are left to enforceBudget. The caller
+// must not hold a shard lock.
+func (c *Cache) makeRoom(entry *CacheEntry) {
+	if c.budget.maxBytes > 0 && entry.size() > c.budget.maxBytes {
+		return
+	}
+	extraBytes, extraEntries := entry.size(), 1
+	s := c.shardFor(entry.key)
+	s.mu.Lock()
+	if elem, ok := s.entries[entry.key]; ok {
+		extraBytes -= elem.Value.(*CacheEntry).footprint()
+		extraEntries = 0
+	}
+	s.mu.Unlock()
+	c.evictFor(extraBytes, extraEntries)
+}
+
+// evictFor evicts the least recently used entries of the whole cache until
+// extraBytes and extraEntries more would fit within its limits.
+func (c *Cache) evictFor(extraBytes, extraEntries int) {
+	if !c.budget.over(extraBytes, extraEntries) {
+		return
+	}
+	c.evictMu.Lock()
+	defer c.evictMu.Unlock()
+	for c.budget.over(extraBytes, extraEntries) {
+		victim := c.oldestShard()
+		if victim == nil {
+			return
+		}
+		victim.mu.Lock()
+		if victim.lru.Len() > 0 && c.budget.over(extraBytes, extraEntries) {
+			victim.evictOldest()
+		}
+		c.unlock(victim)
+	}
+}
+
+// oldestShard returns the shard holding the least recently used entry, or
+// nil if the cache is empty.
+func (c *Cache) oldestShard() *shard {
+	var victim *shard
+	var victimUsed uint64
+	for _, s := range c.shards {
+		s.mu.Lock()
+		used, ok := s.oldest()
+		s.mu.Unlock()
+		if ok && (victim == nil || used < victimUsed) {
+			victim, victimUsed = s, used
+		}
+	}
+	return victim
+}
+
+// Delete removes key from memory and disk, reporting whether it was cached
+// in memory.
+func (c *Cache) Delete(key string) bool {
+	s := c.shardFor(key)
+	s.diskMu.Lock()
+	defer s.diskMu.Unlock()
+	s.mu.Lock()
+	elem, ok := s.entries[key]
+	if ok {
+		s.removeElement(elem).dropped = true
+	}
+	s.mu.Unlock()
+
+	if c.disk != nil {
+		c.disk.remove(key)
+	}
+	return ok
+}
+
+// allEntries collects the entries held in memory and, for a persistent
+// cache, on disk. Entries may be expired or stale.
+func (c *Cache) allEntries() (map[string]*CacheEntry, error) {
+	entries := make(map[string]*CacheEntry)
+	if c.disk != nil {
+		if err := c.disk.each(func(entry *CacheEntry) {
+			entries[entry.key] = entry
+		}); err != nil {
+			return nil, err
+		}
+	}
+	// In-memory entries are at least as recent as their disk copy
+	for _, s := range c.shards {
+		s.mu.Lock()
+		for key, elem := range s.entries {
+			entries[key] = elem.Value.(*CacheEntry)
+		}
+		s.mu.Unlock()
+	}
+	return entries, nil
+}
+
+// Range calls fn with every fresh entry, including those of a persistent
+// cache that are only on disk, until fn returns false. Ranging does not count
+// as hits and reads the whole cache directory.
+func (c *Cache) Range(fn func(key string, val []byte) bool) {
+	if c.closed.Load() {
+		return
+	}
+	entries, err := c.allEntries()
+	if err != nil {
+		return
+	}
+
+	now := time.Now()
+	for key, entry := range entries {
+		if entry.expired(now) {
+			continue
+		}
+		val, err := entry.value()
+		if err != nil {
+			continue
+		}
+		if !fn(key, val) {
+			return
+		}
+	}
+}
+
+// Clear removes every entry from memory and disk. Counters are kept.
+func (c *Cache) Clear() error {
+	return c.clear("")
+}
+
+// clear removes the entries whose keys start with prefix from memory and
+// disk.
+func (c *Cache) clear(prefix string) error {
+	for _, s := range c.shards {
+		s.diskMu.Lock()
+		defer s.diskMu.Unlock()
+		s.mu.Lock()
+		for key, elem := range s.entries {
+			if strings.HasPrefix(key, prefix) {
+				s.removeElement(elem).dropped = true
+			}
+		}
+		s.mu.Unlock()
+	}
+	if c.disk != nil {
+		return c.disk.clear(prefix)
+	}
+	return nil
+}
+
+// Keys returns the sorted keys of the fresh entries, including those of a
+// persistent cache that are only on disk. Unlike Range it never decodes or
+// decompresses values.
+func (c *Cache) Keys() []string {
+	return c.keys("")
+}
+
+// keys is Keys limited to the keys starting with prefix.
+func (c *Cache) keys(prefix string) []string {
+	if c.closed.Load() {
+		return nil
+	}
+
+	now := time.Now()
+	fresh := make(map[string]bool)
+	if c.disk != nil {
+		c.disk.eachHeader(func(header diskHeader, path string) {
+			if strings.HasPrefix(header.Key, prefix) {
+				fresh[header.Key] = now.Sub(header.CreatedAt) <= header.TTL
+			}
+		})
+	}
+	// In-memory entries are at least as recent as their disk copy
+	for _, s := range c.shards {
+		s.mu.Lock()
+		for key, elem := range s.entries {
+			if strings.HasPrefix(key, prefix) {
+				fresh[key] = !elem.Value.(*CacheEntry).expired(now)
+			}
+		}
+		s.mu.Unlock()
+	}
+
+	var keys []string
+	for key, ok := range fresh {
+		if ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Stats returns the current cache counters.
+func (c *Cache) Stats() Stats {
+	var stats Stats
+	now := time.Now()
+	for _, s := range c.shards {
+		s.mu.Lock()
+		stats.Entries += s.lru.Len()
+		stats.Bytes += s.bytes
+		stats.RawBytes += s.rawBytes
+		stats.DecodedBytes += s.decodedBytes
+		stats.Hits += s.hits
+		stats.Misses += s.misses
+		stats.Evictions += s.evictions
+		stats.Expirations += s.expirations
+		for _, elem := range s.entries {
+			if age := now.Sub(elem.Value.(*CacheEntry).createdAt); age > stats.OldestAge {
+				stats.OldestAge = age
+			}
+		}
+		s.mu.Unlock()
+	}
+	return stats
 }
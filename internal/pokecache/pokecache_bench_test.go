@@ -2,6 +2,7 @@ package pokecache
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 )
@@ -117,3 +118,91 @@ func BenchmarkCacheEviction(b *testing.B) {
 		cache.Get(key)
 	}
 }
+
+func BenchmarkCacheAddBounded(b *testing.B) {
+	// Budget holds roughly 100 entries so most adds trigger an eviction
+	cache := NewCache(5*time.Second, WithMaxEntries(100))
+	testData := []byte("test data for a bounded cache")
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		key := fmt.Sprintf("bounded-key-%d", i)
+		cache.Add(key, testData)
+	}
+}
+
+// benchKeys pre-formats keys so parallel benchmarks measure locking rather
+// than string formatting.
+func benchKeys(prefix string, n int) []string {
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("%s-%d", prefix, i)
+	}
+	return keys
+}
+
+func BenchmarkCacheParallelGet(b *testing.B) {
+	testData := []byte("test data for parallel reads")
+	keys := benchKeys("key", 1000)
+
+	for _, shards := range []int{1, DEFAULT_SHARDS} {
+		b.Run(fmt.Sprintf("shards-%d", shards), func(b *testing.B) {
+			cache := NewCache(5*time.Second, WithShards(shards))
+			defer cache.Close()
+
+			for _, key := range keys {
+				cache.Add(key, testData)
+			}
+
+			b.ResetTimer()
+
+			b.RunParallel(func(pb *testing.PB) {
+				i := 0
+				for pb.Next() {
+					cache.Get(keys[i%len(keys)])
+					i++
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkCacheParallelMixed(b *testing.B) {
+	testData := []byte("test data for parallel reads and writes")
+	keys := benchKeys("mixed-key", 1000)
+
+	for _, shards := range []int{1, DEFAULT_SHARDS} {
+		b.Run(fmt.Sprintf("shards-%d", shards), func(b *testing.B) {
+			cache := NewCache(5*time.Second, WithShards(shards))
+			defer cache.Close()
+
+			b.ResetTimer()
+
+			b.RunParallel(func(pb *testing.PB) {
+				i := 0
+				for pb.Next() {
+					key := keys[i%len(keys)]
+					if i%4 == 0 {
+						cache.Add(key, testData)
+					} else {
+						cache.Get(key)
+					}
+					i++
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkCacheGetCompressed(b *testing.B) {
+	cache := NewCache(5*time.Second, WithCompression(1024))
+	largeData := []byte(strings.Repeat(`{"name":"front_default","url":"https://raw.githubusercontent.com/PokeAPI/sprites/"}`, 128))
+	cache.Add("large-key", largeData)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		cache.Get("large-key")
+	}
+}
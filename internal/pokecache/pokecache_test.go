@@ -1,7 +1,12 @@
 package pokecache
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"runtime"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -153,7 +158,6 @@ func TestReapLoopMultipleEntries(t *testing.T) {
 		return
 	}
 
-
 	time.Sleep(shortInterval)
 
 	_, ok2 := cache.Get("key2")
@@ -202,3 +206,444 @@ func TestCacheCreation(t *testing.T) {
 		})
 	}
 }
+
+func TestCloseStopsReaper(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	cache := NewCache(time.Millisecond)
+	if err := cache.Close(); err != nil {
+		t.Errorf("expected no error closing cache, got %v", err)
+		return
+	}
+
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("expected reaper goroutine to exit, goroutines went from %d to %d", before, after)
+		return
+	}
+}
+
+func TestCloseIsIdempotent(t *testing.T) {
+	cache := NewCache(5 * time.Second)
+	cache.Close()
+
+	if err := cache.Close(); err != nil {
+		t.Errorf("expected second close to succeed, got %v", err)
+		return
+	}
+}
+
+func TestAddGetAfterClose(t *testing.T) {
+	cache := NewCache(5 * time.Second)
+	cache.Add("key1", []byte("value1"))
+	cache.Close()
+
+	if err := cache.Add("key2", []byte("value2")); !errors.Is(err, ErrClosed) {
+		t.Errorf("expected ErrClosed from Add after close, got %v", err)
+		return
+	}
+
+	if _, ok := cache.Get("key1"); ok {
+		t.Errorf("expected closed cache to not return entries")
+		return
+	}
+}
+
+func TestErrTellsClosedFromMiss(t *testing.T) {
+	cache := NewCache(5 * time.Second)
+	cache.Add("key1", []byte("value1"))
+
+	if _, ok := cache.Get("missing"); ok || cache.Err() != nil {
+		t.Errorf("expected a miss on an open cache, got err %v", cache.Err())
+		return
+	}
+
+	cache.Close()
+	if _, ok := cache.Get("key1"); ok || !errors.Is(cache.Err(), ErrClosed) {
+		t.Errorf("expected ErrClosed after close, got %v", cache.Err())
+		return
+	}
+}
+
+func TestContextCancelClosesCache(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cache := NewCacheWithContext(ctx, 5*time.Second)
+	cancel()
+
+	select {
+	case <-cache.stopped:
+	case <-time.After(time.Second):
+		t.Errorf("expected reaper to stop after context cancellation")
+		return
+	}
+
+	if err := cache.Add("key", []byte("value")); !errors.Is(err, ErrClosed) {
+		t.Errorf("expected ErrClosed after context cancellation, got %v", err)
+		return
+	}
+	if err := cache.Err(); !errors.Is(err, ErrClosed) {
+		t.Errorf("expected Err to report the cancellation, got %v", err)
+		return
+	}
+}
+
+func TestMaxEntriesEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewCache(5*time.Second, WithMaxEntries(2))
+	defer cache.Close()
+
+	cache.Add("key1", []byte("value1"))
+	cache.Add("key2", []byte("value2"))
+
+	// Touch key1 so key2 becomes the least recently used entry
+	cache.Get("key1")
+	cache.Add("key3", []byte("value3"))
+
+	if _, ok := cache.Get("key2"); ok {
+		t.Errorf("expected key2 to be evicted")
+		return
+	}
+	for _, key := range []string{"key1", "key3"} {
+		if _, ok := cache.Get(key); !ok {
+			t.Errorf("expected %s to still be cached", key)
+			return
+		}
+	}
+
+	stats := cache.Stats()
+	if stats.Entries != 2 || stats.Evictions != 1 {
+		t.Errorf("expected 2 entries and 1 eviction, got %+v", stats)
+		return
+	}
+}
+
+func TestMaxBytesEvictsUntilWithinBudget(t *testing.T) {
+	// Each entry is 4 bytes of key plus 6 bytes of value
+	cache := NewCache(5*time.Second, WithMaxBytes(25))
+	defer cache.Close()
+
+	cache.Add("key1", []byte("value1"))
+	cache.Add("key2", []byte("value2"))
+	cache.Add("key3", []byte("value3"))
+
+	stats := cache.Stats()
+	if stats.Bytes > 25 {
+		t.Errorf("expected at most 25 bytes, got %d", stats.Bytes)
+		return
+	}
+	if stats.Entries != 2 || stats.Evictions != 1 {
+		t.Errorf("expected 2 entries and 1 eviction, got %+v", stats)
+		return
+	}
+	if _, ok := cache.Get("key1"); ok {
+		t.Errorf("expected oldest entry to be evicted")
+		return
+	}
+}
+
+func TestOversizedEntryIsNotKept(t *testing.T) {
+	cache := NewCache(5*time.Second, WithMaxBytes(8))
+	defer cache.Close()
+
+	cache.Add("key1", []byte("a value larger than the budget"))
+
+	if _, ok := cache.Get("key1"); ok {
+		t.Errorf("expected oversized entry to be evicted")
+		return
+	}
+	if stats := cache.Stats(); stats.Bytes != 0 || stats.Entries != 0 {
+		t.Errorf("expected empty cache, got %+v", stats)
+		return
+	}
+}
+
+func TestStatsBytesTracksUpdatesAndExpiry(t *testing.T) {
+	const interval = 10 * time.Millisecond
+	cache := NewCache(interval)
+	defer cache.Close()
+
+	cache.Add("key1", []byte("value1"))
+	cache.Add("key1", []byte("longer value1"))
+
+	if stats := cache.Stats(); stats.Bytes != len("key1")+len("longer value1") {
+		t.Errorf("expected bytes to reflect updated value, got %d", stats.Bytes)
+		return
+	}
+
+	deadline := time.Now().Add(interval * 50)
+	stats := cache.Stats()
+	for (stats.Bytes != 0 || stats.Entries != 0) && time.Now().Before(deadline) {
+		time.Sleep(interval)
+		stats = cache.Stats()
+	}
+	if stats.Bytes != 0 || stats.Entries != 0 || stats.Evictions != 0 {
+		t.Errorf("expected expiry to free bytes without counting evictions, got %+v", stats)
+		return
+	}
+}
+
+func TestAddWithTTLExpiresBeforeInterval(t *testing.T) {
+	const ttl = 10 * time.Millisecond
+	cache := NewCache(5 * time.Second)
+	defer cache.Close()
+
+	cache.AddWithTTL("short", []byte("value"), ttl)
+	cache.Add("default", []byte("value"))
+
+	time.Sleep(ttl * 2)
+
+	if _, ok := cache.Get("short"); ok {
+		t.Errorf("expected short-lived entry to expire")
+		return
+	}
+	if _, ok := cache.Get("default"); !ok {
+		t.Errorf("expected default entry to still be cached")
+		return
+	}
+}
+
+func TestAddWithTTLOutlivesReap(t *testing.T) {
+	const interval = 5 * time.Millisecond
+	cache := NewCache(interval)
+	defer cache.Close()
+
+	cache.AddWithTTL("long", []byte("value"), time.Hour)
+
+	time.Sleep(interval * 4)
+
+	if _, ok := cache.Get("long"); !ok {
+		t.Errorf("expected long-lived entry to survive reap ticks")
+		return
+	}
+}
+
+func TestTTLPolicy(t *testing.T) {
+	const interval = 10 * time.Millisecond
+	policy := func(key string) time.Duration {
+		if strings.HasPrefix(key, "/pokemon/") {
+			return time.Hour
+		}
+		return 0
+	}
+	cache := NewCache(interval, WithTTLPolicy(policy))
+	defer cache.Close()
+
+	cache.Add("/pokemon/pikachu", []byte("value"))
+	cache.Add("/location-area", []byte("value"))
+
+	time.Sleep(interval * 3)
+
+	if _, ok := cache.Get("/pokemon/pikachu"); !ok {
+		t.Errorf("expected policy TTL to keep /pokemon/ entry")
+		return
+	}
+	if _, ok := cache.Get("/location-area"); ok {
+		t.Errorf("expected unmatched entry to expire after the interval")
+		return
+	}
+}
+
+func TestStatsCountsHitsMissesAndExpirations(t *testing.T) {
+	const ttl = 10 * time.Millisecond
+	cache := NewCache(5 * time.Second)
+	defer cache.Close()
+
+	cache.Add("key1", []byte("value1"))
+	cache.AddWithTTL("key2", []byte("value2"), ttl)
+
+	cache.Get("key1")
+	cache.Get("key1")
+	cache.Get("missing")
+
+	time.Sleep(ttl * 2)
+	cache.Get("key2")
+
+	stats := cache.Stats()
+	if stats.Hits != 2 || stats.Misses != 2 || stats.Expirations != 1 {
+		t.Errorf("expected 2 hits, 2 misses and 1 expiration, got %+v", stats)
+		return
+	}
+	if stats.Entries != 1 {
+		t.Errorf("expected 1 entry, got %d", stats.Entries)
+		return
+	}
+	if stats.OldestAge < ttl {
+		t.Errorf("expected oldest age of at least %v, got %v", ttl, stats.OldestAge)
+		return
+	}
+}
+
+func TestKeysAreSorted(t *testing.T) {
+	cache := NewCache(5 * time.Second)
+	defer cache.Close()
+
+	cache.Add("key3", []byte("value3"))
+	cache.Add("key1", []byte("value1"))
+	cache.Add("key2", []byte("value2"))
+
+	keys := cache.Keys()
+	expected := []string{"key1", "key2", "key3"}
+	if len(keys) != len(expected) {
+		t.Errorf("expected %d keys, got %d", len(expected), len(keys))
+		return
+	}
+	for i := range expected {
+		if keys[i] != expected[i] {
+			t.Errorf("expected keys %v, got %v", expected, keys)
+			return
+		}
+	}
+}
+
+func TestDeleteAndClear(t *testing.T) {
+	cache := NewCache(5 * time.Second)
+	defer cache.Close()
+
+	cache.Add("key1", []byte("value1"))
+	cache.Add("key2", []byte("value2"))
+
+	if !cache.Delete("key1") {
+		t.Errorf("expected Delete to report the key was cached")
+		return
+	}
+	if cache.Delete("key1") {
+		t.Errorf("expected second Delete to report nothing was removed")
+		return
+	}
+	if _, ok := cache.Get("key1"); ok {
+		t.Errorf("expected deleted key to be gone")
+		return
+	}
+
+	if err := cache.Clear(); err != nil {
+		t.Errorf("expected no error clearing cache, got %v", err)
+		return
+	}
+	if stats := cache.Stats(); stats.Entries != 0 || stats.Bytes != 0 {
+		t.Errorf("expected empty cache after Clear, got %+v", stats)
+		return
+	}
+}
+
+func TestPeekServesStaleEntries(t *testing.T) {
+	const ttl = 10 * time.Millisecond
+	cache := NewCache(ttl, WithStaleWindow(time.Hour))
+	defer cache.Close()
+
+	cache.AddEntry("key1", Entry{Val: []byte("value1"), ETag: `"abc"`})
+
+	time.Sleep(ttl * 3)
+
+	if _, ok := cache.Get("key1"); ok {
+		t.Errorf("expected Get to miss on a stale entry")
+		return
+	}
+
+	entry, ok := cache.Peek("key1")
+	if !ok {
+		t.Errorf("expected Peek to return the stale entry")
+		return
+	}
+	if !entry.Stale() || string(entry.Val) != "value1" || entry.ETag != `"abc"` {
+		t.Errorf("expected stale entry with its validators, got %+v", entry)
+		return
+	}
+}
+
+func TestStaleWindowElapsed(t *testing.T) {
+	const ttl = 5 * time.Millisecond
+	cache := NewCache(ttl, WithStaleWindow(ttl))
+	defer cache.Close()
+
+	cache.Add("key1", []byte("value1"))
+
+	time.Sleep(ttl * 4)
+
+	if _, ok := cache.Peek("key1"); ok {
+		t.Errorf("expected entry to be dropped after the stale window")
+		return
+	}
+}
+
+func TestShardedBudgetStaysWithinLimit(t *testing.T) {
+	// 10 does not divide evenly between the default 16 shards
+	const maxEntries = 10
+	cache := NewCache(5*time.Second, WithMaxEntries(maxEntries))
+	defer cache.Close()
+
+	for i := 0; i < 1000; i++ {
+		cache.Add(fmt.Sprintf("key-%d", i), []byte("value"))
+	}
+
+	stats := cache.Stats()
+	if stats.Entries != maxEntries {
+		t.Errorf("expected exactly %d entries, got %d", maxEntries, stats.Entries)
+		return
+	}
+	for i := 1000 - maxEntries; i < 1000; i++ {
+		if _, ok := cache.Get(fmt.Sprintf("key-%d", i)); !ok {
+			t.Errorf("expected the %d most recent entries to be kept, key-%d is missing", maxEntries, i)
+			return
+		}
+	}
+	if stats.Evictions != uint64(1000-stats.Entries) {
+		t.Errorf("expected every dropped entry to count as an eviction, got %+v", stats)
+		return
+	}
+}
+
+func TestShardedBudgetKeepsEntryLargerThanShardShare(t *testing.T) {
+	const maxBytes = 1 << 20
+	cache := NewCache(5*time.Second, WithMaxBytes(maxBytes))
+	defer cache.Close()
+
+	// Far more than maxBytes divided by the default shard count
+	val := make([]byte, 200<<10)
+	cache.Add("large", val)
+
+	if _, ok := cache.Get("large"); !ok {
+		t.Errorf("expected an entry within the cache budget to be kept")
+		return
+	}
+	if stats := cache.Stats(); stats.Entries != 1 || stats.Evictions != 0 {
+		t.Errorf("expected 1 entry and no evictions, got %+v", stats)
+		return
+	}
+
+	for i := 0; i < 10; i++ {
+		cache.Add(fmt.Sprintf("key-%d", i), val)
+	}
+	if stats := cache.Stats(); stats.Bytes > maxBytes {
+		t.Errorf("expected at most %d bytes, got %d", maxBytes, stats.Bytes)
+		return
+	}
+}
+
+func TestShardedCacheConcurrentAccess(t *testing.T) {
+	cache := NewCache(5 * time.Second)
+	defer cache.Close()
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				key := fmt.Sprintf("key-%d-%d", g, i)
+				cache.Add(key, []byte(key))
+				if val, ok := cache.Get(key); !ok || string(val) != key {
+					t.Errorf("expected to read back %s", key)
+					return
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	if stats := cache.Stats(); stats.Entries != 800 || stats.Hits != 800 {
+		t.Errorf("expected 800 entries and hits, got %+v", stats)
+		return
+	}
+	if keys := cache.Keys(); len(keys) != 800 {
+		t.Errorf("expected 800 keys, got %d", len(keys))
+		return
+	}
+}
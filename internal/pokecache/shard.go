@@ -0,0 +1,155 @@
+package pokecache
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DEFAULT_SHARDS is the number of shards used unless WithShards is given.
+const DEFAULT_SHARDS int = 16
+
+// budget holds the cache-wide size limits and the totals every shard adds
+// to, so the limits hold for the cache as a whole whatever the shard count.
+type budget struct {
+	maxBytes   int
+	maxEntries int
+	bytes      atomic.Int64
+	entries    atomic.Int64
+	// clock stamps entries as they are used so the least recently used
+	// entry of the whole cache can be found across shards.
+	clock atomic.Uint64
+}
+
+// over reports whether the cache would exceed its limits if it held extra
+// more bytes and entries.
+func (b *budget) over(extraBytes, extraEntries int) bool {
+	if b.maxBytes > 0 && b.bytes.Load()+int64(extraBytes) > int64(b.maxBytes) {
+		return true
+	}
+	return b.maxEntries > 0 && b.entries.Load()+int64(extraEntries) > int64(b.maxEntries)
+}
+
+// shard is an independently locked slice of the cache key space with its own
+// LRU order and counters.
+type shard struct {
+	mu sync.Mutex
+	// diskMu serializes the disk writes and deletes of the shard's keys, and
+	// disk reads that may put an entry back in memory, so they land in the
+	// order they were made. It is taken before mu and disk I/O happens
+	// without holding mu.
+	diskMu sync.Mutex
+	// entries maps keys to elements of lru holding a *CacheEntry. The front
+	// of lru is the most recently used entry.
+	entries  map[string]*list.Element
+	lru      *list.List
+	bytes    int
+	rawBytes int
+	// decodedBytes is the estimated size of the memoized decoded values,
+	// counted against the budget on top of bytes.
+	decodedBytes int
+	budget       *budget
+	hits         uint64
+	misses       uint64
+	evictions    uint64
+	expirations  uint64
+	// observed is the owning cache's flag for whether anyone subscribed to
+	// events. pending holds the events recorded under mu.
+	observed *atomic.Bool
+	pending  []Event
+}
+
+func newShard(b *budget, observed *atomic.Bool) *shard {
+	return &shard{
+		entries:  make(map[string]*list.Element),
+		lru:      list.New(),
+		budget:   b,
+		observed: observed,
+	}
+}
+
+// insert stores entry as the most recently used one. It may leave the cache
+// over budget; the caller must call Cache.enforceBudget once it has released
+// s.mu. The caller must hold s.mu.
+func (s *shard) insert(entry *CacheEntry) {
+	if elem, ok := s.entries[entry.key]; ok {
+		s.removeElement(elem).dropped = true
+	}
+	entry.used = s.budget.clock.Add(1)
+	s.entries[entry.key] = s.lru.PushFront(entry)
+	s.bytes += entry.size()
+	s.rawBytes += len(entry.key) + entry.rawSize
+	s.budget.bytes.Add(int64(entry.footprint()))
+	s.budget.entries.Add(1)
+}
+
+// setDecoded memoizes v, estimated at size bytes, on entry. The caller must
+// hold s.mu.
+func (s *shard) setDecoded(entry *CacheEntry, v any, size int) {
+	delta := size - entry.decodedSize
+	entry.decoded, entry.decodedSize = v, size
+	s.decodedBytes += delta
+	s.budget.bytes.Add(int64(delta))
+}
+
+// touch marks elem as the most recently used entry. The caller must hold
+// s.mu.
+func (s *shard) touch(elem *list.Element) {
+	elem.Value.(*CacheEntry).used = s.budget.clock.Add(1)
+	s.lru.MoveToFront(elem)
+}
+
+// removeElement drops elem from the shard and returns its entry. The caller
+// must hold s.mu.
+func (s *shard) removeElement(elem *list.Element) *CacheEntry {
+	entry := s.lru.Remove(elem).(*CacheEntry)
+	delete(s.entries, entry.key)
+	s.bytes -= entry.size()
+	s.rawBytes -= len(entry.key) + entry.rawSize
+	s.decodedBytes -= entry.decodedSize
+	s.budget.bytes.Add(-int64(entry.footprint()))
+	s.budget.entries.Add(-1)
+	return entry
+}
+
+// evictOldest drops the least recently used entry of the shard. The caller
+// must hold s.mu.
+func (s *shard) evictOldest() {
+	evicted := s.removeElement(s.lru.Back())
+	s.evictions++
+	s.record(EventEvict, evicted.key, evicted.size())
+}
+
+// oldest returns the use stamp of the least recently used entry of the
+// shard, or false if the shard is empty. The caller must hold s.mu.
+func (s *shard) oldest() (uint64, bool) {
+	back := s.lru.Back()
+	if back == nil {
+		return 0, false
+	}
+	return back.Value.(*CacheEntry).used, true
+}
+
+// reset drops every entry. The caller must hold s.mu.
+func (s *shard) reset() {
+	s.budget.bytes.Add(-int64(s.bytes + s.decodedBytes))
+	s.budget.entries.Add(-int64(s.lru.Len()))
+	s.entries = make(map[string]*list.Element)
+	s.lru.Init()
+	s.bytes = 0
+	s.rawBytes = 0
+	s.decodedBytes = 0
+}
+
+// reap drops the entries that have outlived their TTL and the stale window.
+// The caller must hold s.mu.
+func (s *shard) reap(now time.Time, staleWindow time.Duration) {
+	for _, elem := range s.entries {
+		if entry := elem.Value.(*CacheEntry); entry.retired(now, staleWindow) {
+			s.removeElement(elem)
+			s.expirations++
+			s.record(EventExpire, entry.key, entry.size())
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package pokecache
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const SNAPSHOT_FORMAT string = "pokedex-cache-snapshot"
+const SNAPSHOT_VERSION int = 1
+
+// ErrInvalidSnapshot is returned by Import for data not written by Export.
+var ErrInvalidSnapshot = errors.New("not a cache snapshot")
+
+type snapshotHeader struct {
+	Format     string    `json:"format"`
+	Version    int       `json:"version"`
+	ExportedAt time.Time `json:"exported_at"`
+}
+
+// snapshotEntry always carries the uncompressed value so a snapshot can be
+// imported regardless of the compression settings on either side.
+type snapshotEntry struct {
+	Key          string        `json:"key"`
+	CreatedAt    time.Time     `json:"created_at"`
+	TTL          time.Duration `json:"ttl"`
+	Val          []byte        `json:"val"`
+	ETag         string        `json:"etag,omitempty"`
+	LastModified string        `json:"last_modified,omitempty"`
+}
+
+// Export writes every entry that has not outlived its TTL and stale window to
+// w as a gzipped stream of JSON lines, keeping the original createdAt so the
+// entries expire on the importing side as they would have here. Entries of a
+// persistent cache that are only on disk are included. It returns the number
+// of entries written.
+func (c *Cache) Export(w io.Writer) (int, error) {
+	return c.exportPrefix(w, "")
+}
+
+// exportPrefix is Export limited to the keys starting with prefix.
+func (c *Cache) exportPrefix(w io.Writer, prefix string) (int, error) {
+	if c.closed.Load() {
+		return 0, ErrClosed
+	}
+
+	now := time.Now()
+	entries, err := c.allEntries()
+	if err != nil {
+		return 0, err
+	}
+
+	zw := gzip.NewWriter(w)
+	enc := json.NewEncoder(zw)
+	err = enc.Encode(snapshotHeader{
+		Format:     SNAPSHOT_FORMAT,
+		Version:    SNAPSHOT_VERSION,
+		ExportedAt: now,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("Error writing snapshot: %w", err)
+	}
+
+	written := 0
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.key, prefix) || entry.retired(now, c.staleWindow) {
+			continue
+		}
+		val, err := entry.value()
+		if err != nil {
+			continue
+		}
+		err = enc.Encode(snapshotEntry{
+			Key:          entry.key,
+			CreatedAt:    entry.createdAt,
+			TTL:          entry.ttl,
+			Val:          val,
+			ETag:         entry.etag,
+			LastModified: entry.lastModified,
+		})
+		if err != nil {
+			return written, fmt.Errorf("Error writing snapshot: %w", err)
+		}
+		written++
+	}
+
+	if err := zw.Close(); err != nil {
+		return written, fmt.Errorf("Error writing snapshot: %w", err)
+	}
+	return written, nil
+}
+
+// Import adds the entries of a snapshot written by Export, keeping their
+// createdAt and TTL. Entries that have outlived their TTL and this cache's
+// stale window are skipped. It returns the number of entries imported.
+func (c *Cache) Import(r io.Reader) (int, error) {
+	return c.importPrefix(r, "")
+}
+
+// importPrefix is Import limited to the keys starting with prefix.
+func (c *Cache) importPrefix(r io.Reader, prefix string) (int, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidSnapshot, err)
+	}
+	defer zr.Close()
+
+	dec := json.NewDecoder(bufio.NewReader(zr))
+	var header snapshotHeader
+	if err := dec.Decode(&header); err != nil || header.Format != SNAPSHOT_FORMAT {
+		return 0, ErrInvalidSnapshot
+	}
+	if header.Version > SNAPSHOT_VERSION {
+		return 0, fmt.Errorf("%w: unsupported version %d", ErrInvalidSnapshot, header.Version)
+	}
+
+	imported := 0
+	now := time.Now()
+	for {
+		var entry snapshotEntry
+		err := dec.Decode(&entry)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return imported, fmt.Errorf("Error reading snapshot: %w", err)
+		}
+
+		if !strings.HasPrefix(entry.Key, prefix) || now.Sub(entry.CreatedAt) > entry.TTL+c.staleWindow {
+			continue
+		}
+		err = c.AddEntry(entry.Key, Entry{
+			Val:          entry.Val,
+			CreatedAt:    entry.CreatedAt,
+			TTL:          entry.TTL,
+			ETag:         entry.ETag,
+			LastModified: entry.LastModified,
+		})
+		if err != nil {
+			return imported, err
+		}
+		imported++
+	}
+	return imported, nil
+}
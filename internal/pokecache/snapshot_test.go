@@ -0,0 +1,132 @@
+package pokecache
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestExportImportRoundTrip(t *testing.T) {
+	source := NewCache(5 * time.Second)
+	defer source.Close()
+
+	source.AddEntry("key1", Entry{Val: []byte("value1"), TTL: time.Hour, ETag: `"abc"`})
+	source.Add("key2", []byte("value2"))
+
+	var buf bytes.Buffer
+	written, err := source.Export(&buf)
+	if err != nil {
+		t.Errorf("expected no error exporting, got %v", err)
+		return
+	}
+	if written != 2 {
+		t.Errorf("expected 2 entries exported, got %d", written)
+		return
+	}
+
+	original, _ := source.Peek("key1")
+
+	target := NewCache(5 * time.Second)
+	defer target.Close()
+
+	imported, err := target.Import(&buf)
+	if err != nil {
+		t.Errorf("expected no error importing, got %v", err)
+		return
+	}
+	if imported != 2 {
+		t.Errorf("expected 2 entries imported, got %d", imported)
+		return
+	}
+
+	entry, ok := target.Peek("key1")
+	if !ok {
+		t.Errorf("expected imported key to be cached")
+		return
+	}
+	if string(entry.Val) != "value1" || entry.ETag != `"abc"` || entry.TTL != time.Hour {
+		t.Errorf("expected entry to round trip, got %+v", entry)
+		return
+	}
+	if !entry.CreatedAt.Equal(original.CreatedAt) {
+		t.Errorf("expected createdAt %v to be kept, got %v", original.CreatedAt, entry.CreatedAt)
+		return
+	}
+}
+
+func TestImportSkipsExpiredEntries(t *testing.T) {
+	const ttl = 10 * time.Millisecond
+	source := NewCache(5*time.Second, WithStaleWindow(time.Hour))
+	defer source.Close()
+
+	source.AddWithTTL("short", []byte("value"), ttl)
+	source.AddWithTTL("long", []byte("value"), time.Hour)
+
+	var buf bytes.Buffer
+	source.Export(&buf)
+	time.Sleep(ttl * 2)
+
+	// The target has no stale window, so the short entry is already dead
+	target := NewCache(5 * time.Second)
+	defer target.Close()
+
+	imported, err := target.Import(&buf)
+	if err != nil {
+		t.Errorf("expected no error importing, got %v", err)
+		return
+	}
+	if imported != 1 {
+		t.Errorf("expected only the live entry to be imported, got %d", imported)
+		return
+	}
+	if _, ok := target.Get("long"); !ok {
+		t.Errorf("expected long-lived entry to be imported")
+		return
+	}
+}
+
+func TestExportIncludesDiskOnlyEntries(t *testing.T) {
+	dir := t.TempDir()
+
+	writer, err := NewPersistentCache(5*time.Second, dir, WithCompression(1))
+	if err != nil {
+		t.Errorf("expected no error creating cache, got %v", err)
+		return
+	}
+	writer.Add("key1", []byte("value1 value1 value1 value1"))
+	writer.Close()
+
+	reopened, err := NewPersistentCache(5*time.Second, dir)
+	if err != nil {
+		t.Errorf("expected no error reopening cache, got %v", err)
+		return
+	}
+	defer reopened.Close()
+
+	var buf bytes.Buffer
+	if written, err := reopened.Export(&buf); err != nil || written != 1 {
+		t.Errorf("expected 1 entry exported from disk, got %d and %v", written, err)
+		return
+	}
+
+	target := NewCache(5 * time.Second)
+	defer target.Close()
+	target.Import(&buf)
+
+	if val, ok := target.Get("key1"); !ok || string(val) != "value1 value1 value1 value1" {
+		t.Errorf("expected disk entry to be exported uncompressed, got %q", val)
+		return
+	}
+}
+
+func TestImportRejectsInvalidData(t *testing.T) {
+	cache := NewCache(5 * time.Second)
+	defer cache.Close()
+
+	_, err := cache.Import(bytes.NewReader([]byte("definitely not a snapshot")))
+	if !errors.Is(err, ErrInvalidSnapshot) {
+		t.Errorf("expected ErrInvalidSnapshot, got %v", err)
+		return
+	}
+}
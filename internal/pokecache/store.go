@@ -0,0 +1,276 @@
+package pokecache
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Store is the storage contract the API client depends on. Cache is the
+// in-memory (optionally disk-backed) implementation; DiskStore, TieredStore,
+// NopStore and Namespaced views are the others.
+type Store interface {
+	Get(key string) ([]byte, bool)
+	Add(key string, val []byte) error
+	Delete(key string) bool
+	Range(fn func(key string, val []byte) bool)
+	Close() error
+}
+
+// EntryStore is implemented by stores that keep creation times and HTTP
+// validators, and can hand out stale entries for revalidation.
+type EntryStore interface {
+	Store
+	Peek(key string) (Entry, bool)
+	AddEntry(key string, e Entry) error
+}
+
+// decodedStore is implemented by stores that can memoize decoded values for
+// Typed views.
+type decodedStore interface {
+	getEntry(key string) (*CacheEntry, any, bool)
+	setDecoded(key string, entry *CacheEntry, v any)
+}
+
+// Peek returns the entry for key from s, stale or not, if s is an
+// EntryStore. Other stores only report fresh values, without metadata.
+func Peek(s Store, key string) (Entry, bool) {
+	if es, ok := s.(EntryStore); ok {
+		return es.Peek(key)
+	}
+	val, ok := s.Get(key)
+	if !ok {
+		return Entry{}, false
+	}
+	return Entry{Val: val}, true
+}
+
+// Put stores e in s, keeping its metadata if s is an EntryStore.
+func Put(s Store, key string, e Entry) error {
+	if es, ok := s.(EntryStore); ok {
+		return es.AddEntry(key, e)
+	}
+	return s.Add(key, e.Val)
+}
+
+// NopStore never stores anything, which disables caching.
+type NopStore struct{}
+
+func (NopStore) Get(key string) ([]byte, bool)              { return nil, false }
+func (NopStore) Add(key string, val []byte) error           { return nil }
+func (NopStore) Delete(key string) bool                     { return false }
+func (NopStore) Range(fn func(key string, val []byte) bool) {}
+func (NopStore) Close() error                               { return nil }
+
+// TieredStore layers a fast front store over a slower back store, e.g. a
+// Cache over a DiskStore. Writes go to both; reads that miss the front are
+// served from the back and promoted to the front.
+type TieredStore struct {
+	front Store
+	back  Store
+}
+
+func NewTieredStore(front, back Store) *TieredStore {
+	return &TieredStore{front: front, back: back}
+}
+
+func (t *TieredStore) Get(key string) ([]byte, bool) {
+	if val, ok := t.front.Get(key); ok {
+		return val, true
+	}
+	val, ok := t.back.Get(key)
+	if !ok {
+		return nil, false
+	}
+	t.promote(key)
+	return val, true
+}
+
+func (t *TieredStore) promote(key string) {
+	if entry, ok := Peek(t.back, key); ok {
+		Put(t.front, key, entry)
+	}
+}
+
+func (t *TieredStore) Peek(key string) (Entry, bool) {
+	if entry, ok := Peek(t.front, key); ok {
+		return entry, true
+	}
+	entry, ok := Peek(t.back, key)
+	if ok {
+		Put(t.front, key, entry)
+	}
+	return entry, ok
+}
+
+func (t *TieredStore) Add(key string, val []byte) error {
+	return t.AddEntry(key, Entry{Val: val})
+}
+
+// AddEntry writes e to both tiers, returning the first error.
+func (t *TieredStore) AddEntry(key string, e Entry) error {
+	frontErr := Put(t.front, key, e)
+	backErr := Put(t.back, key, e)
+	if frontErr != nil {
+		return frontErr
+	}
+	return backErr
+}
+
+func (t *TieredStore) Delete(key string) bool {
+	inFront := t.front.Delete(key)
+	inBack := t.back.Delete(key)
+	return inFront || inBack
+}
+
+// Range visits the back tier, then front entries the back does not have.
+func (t *TieredStore) Range(fn func(key string, val []byte) bool) {
+	seen := make(map[string]bool)
+	stopped := false
+	t.back.Range(func(key string, val []byte) bool {
+		seen[key] = true
+		if !fn(key, val) {
+			stopped = true
+			return false
+		}
+		return true
+	})
+	if stopped {
+		return
+	}
+	t.front.Range(func(key string, val []byte) bool {
+		if seen[key] {
+			return true
+		}
+		return fn(key, val)
+	})
+}
+
+func (t *TieredStore) Close() error {
+	frontErr := t.front.Close()
+	backErr := t.back.Close()
+	if frontErr != nil {
+		return frontErr
+	}
+	return backErr
+}
+
+// NAMESPACE_SEPARATOR joins a namespace and a key.
+const NAMESPACE_SEPARATOR string = "|"
+
+// NamespacedStore is a view of a shared store that prefixes every key with a
+// namespace, so e.g. a mirror and the real PokéAPI never share entries.
+type NamespacedStore struct {
+	store  Store
+	prefix string
+}
+
+// Namespaced returns a view of s whose keys live under namespace.
+func Namespaced(s Store, namespace string) *NamespacedStore {
+	return &NamespacedStore{store: s, prefix: namespace + NAMESPACE_SEPARATOR}
+}
+
+func (n *NamespacedStore) Get(key string) ([]byte, bool) {
+	return n.store.Get(n.prefix + key)
+}
+
+func (n *NamespacedStore) Add(key string, val []byte) error {
+	return n.store.Add(n.prefix+key, val)
+}
+
+func (n *NamespacedStore) Peek(key string) (Entry, bool) {
+	return Peek(n.store, n.prefix+key)
+}
+
+func (n *NamespacedStore) AddEntry(key string, e Entry) error {
+	return Put(n.store, n.prefix+key, e)
+}
+
+func (n *NamespacedStore) Delete(key string) bool {
+	return n.store.Delete(n.prefix + key)
+}
+
+// Range visits the entries of this namespace with the prefix removed.
+func (n *NamespacedStore) Range(fn func(key string, val []byte) bool) {
+	n.store.Range(func(key string, val []byte) bool {
+		if !strings.HasPrefix(key, n.prefix) {
+			return true
+		}
+		return fn(strings.TrimPrefix(key, n.prefix), val)
+	})
+}
+
+// Keys returns the sorted keys of the fresh entries of this namespace with
+// the prefix removed. Over a Cache values are never decoded.
+func (n *NamespacedStore) Keys() []string {
+	var keys []string
+	if c, ok := n.store.(*Cache); ok {
+		for _, key := range c.keys(n.prefix) {
+			keys = append(keys, strings.TrimPrefix(key, n.prefix))
+		}
+		return keys
+	}
+	n.Range(func(key string, val []byte) bool {
+		keys = append(keys, key)
+		return true
+	})
+	sort.Strings(keys)
+	return keys
+}
+
+// Clear removes the entries of this namespace, leaving the other namespaces
+// of the shared store untouched.
+func (n *NamespacedStore) Clear() error {
+	if c, ok := n.store.(*Cache); ok {
+		return c.clear(n.prefix)
+	}
+	for _, key := range n.Keys() {
+		n.Delete(key)
+	}
+	return nil
+}
+
+// Export writes the entries of this namespace like Cache.Export. Keys keep
+// their namespace, so the snapshot restores into the same namespace.
+func (n *NamespacedStore) Export(w io.Writer) (int, error) {
+	c, ok := n.store.(*Cache)
+	if !ok {
+		return 0, fmt.Errorf("%w: snapshots need a Cache", errors.ErrUnsupported)
+	}
+	return c.exportPrefix(w, n.prefix)
+}
+
+// Import adds the entries of a snapshot that belong to this namespace like
+// Cache.Import, skipping those of other namespaces.
+func (n *NamespacedStore) Import(r io.Reader) (int, error) {
+	c, ok := n.store.(*Cache)
+	if !ok {
+		return 0, fmt.Errorf("%w: snapshots need a Cache", errors.ErrUnsupported)
+	}
+	return c.importPrefix(r, n.prefix)
+}
+
+// Close does nothing: the underlying store is shared and is closed by its
+// owner.
+func (n *NamespacedStore) Close() error {
+	return nil
+}
+
+func (n *NamespacedStore) getEntry(key string) (*CacheEntry, any, bool) {
+	if ds, ok := n.store.(decodedStore); ok {
+		return ds.getEntry(n.prefix + key)
+	}
+	val, ok := n.store.Get(n.prefix + key)
+	if !ok {
+		return nil, nil, false
+	}
+	return &CacheEntry{key: key, val: val}, nil, true
+}
+
+func (n *NamespacedStore) setDecoded(key string, entry *CacheEntry, v any) {
+	if ds, ok := n.store.(decodedStore); ok {
+		ds.setDecoded(n.prefix+key, entry, v)
+	}
+}
@@ -0,0 +1,236 @@
+package pokecache
+
+import (
+	"bytes"
+	"sort"
+	"testing"
+	"time"
+)
+
+func rangeKeys(s Store) []string {
+	var keys []string
+	s.Range(func(key string, val []byte) bool {
+		keys = append(keys, key)
+		return true
+	})
+	sort.Strings(keys)
+	return keys
+}
+
+func TestNopStore(t *testing.T) {
+	var s Store = NopStore{}
+
+	if err := s.Add("key1", []byte("value1")); err != nil {
+		t.Errorf("expected no error, got %v", err)
+		return
+	}
+	if _, ok := s.Get("key1"); ok {
+		t.Errorf("expected NopStore to never hit")
+		return
+	}
+	if keys := rangeKeys(s); len(keys) != 0 {
+		t.Errorf("expected no keys, got %v", keys)
+		return
+	}
+}
+
+func TestNamespacedStoreIsolatesKeys(t *testing.T) {
+	cache := NewCache(5 * time.Second)
+	defer cache.Close()
+
+	live := Namespaced(cache, "pokeapi.co/api/v2")
+	mirror := Namespaced(cache, "127.0.0.1:8080/api/v2")
+
+	live.Add("/pokemon/pikachu", []byte("live"))
+	mirror.Add("/pokemon/pikachu", []byte("mirror"))
+
+	if val, ok := live.Get("/pokemon/pikachu"); !ok || string(val) != "live" {
+		t.Errorf("expected live value, got %q", val)
+		return
+	}
+	if val, ok := mirror.Get("/pokemon/pikachu"); !ok || string(val) != "mirror" {
+		t.Errorf("expected mirror value, got %q", val)
+		return
+	}
+
+	if keys := rangeKeys(live); len(keys) != 1 || keys[0] != "/pokemon/pikachu" {
+		t.Errorf("expected only the unprefixed live key, got %v", keys)
+		return
+	}
+
+	if !mirror.Delete("/pokemon/pikachu") {
+		t.Errorf("expected mirror delete to remove the key")
+		return
+	}
+	if _, ok := live.Get("/pokemon/pikachu"); !ok {
+		t.Errorf("expected live key to survive a mirror delete")
+		return
+	}
+}
+
+func TestNamespacedStoreScopesKeysClearAndSnapshots(t *testing.T) {
+	cache := NewCache(5 * time.Second)
+	defer cache.Close()
+
+	live := Namespaced(cache, "pokeapi.co/api/v2")
+	mirror := Namespaced(cache, "127.0.0.1:8080/api/v2")
+	live.Add("/pokemon/pikachu", []byte("live"))
+	live.Add("/pokemon/eevee", []byte("live"))
+	mirror.Add("/pokemon/pikachu", []byte("mirror"))
+
+	if keys := live.Keys(); len(keys) != 2 || keys[0] != "/pokemon/eevee" || keys[1] != "/pokemon/pikachu" {
+		t.Errorf("expected the sorted unprefixed live keys, got %v", keys)
+		return
+	}
+
+	var buf bytes.Buffer
+	if written, err := live.Export(&buf); err != nil || written != 2 {
+		t.Errorf("expected the 2 live entries exported, got %d and %v", written, err)
+		return
+	}
+	snapshot := buf.Bytes()
+
+	if err := mirror.Clear(); err != nil {
+		t.Errorf("expected no error clearing the mirror, got %v", err)
+		return
+	}
+	if keys := mirror.Keys(); len(keys) != 0 {
+		t.Errorf("expected the mirror to be empty, got %v", keys)
+		return
+	}
+	if keys := live.Keys(); len(keys) != 2 {
+		t.Errorf("expected clearing the mirror to keep the live entries, got %v", keys)
+		return
+	}
+
+	if imported, err := mirror.Import(bytes.NewReader(snapshot)); err != nil || imported != 0 {
+		t.Errorf("expected a live snapshot to import nothing into the mirror, got %d and %v", imported, err)
+		return
+	}
+	target := NewCache(5 * time.Second)
+	defer target.Close()
+	if imported, err := Namespaced(target, "pokeapi.co/api/v2").Import(bytes.NewReader(snapshot)); err != nil || imported != 2 {
+		t.Errorf("expected the live snapshot to import into the live namespace, got %d and %v", imported, err)
+		return
+	}
+}
+
+func TestNamespacedStoreCloseKeepsSharedStore(t *testing.T) {
+	cache := NewCache(5 * time.Second)
+	defer cache.Close()
+
+	ns := Namespaced(cache, "ns")
+	ns.Close()
+
+	if err := ns.Add("key1", []byte("value1")); err != nil {
+		t.Errorf("expected shared cache to stay open, got %v", err)
+		return
+	}
+}
+
+func TestTieredStorePromotesBackHits(t *testing.T) {
+	front := NewCache(5 * time.Second)
+	defer front.Close()
+	back, err := NewDiskStore(t.TempDir(), time.Minute)
+	if err != nil {
+		t.Fatalf("failed to create disk store: %v", err)
+	}
+	tiered := NewTieredStore(front, back)
+
+	back.AddEntry("key1", Entry{Val: []byte("value1"), ETag: `"v1"`})
+
+	if val, ok := tiered.Get("key1"); !ok || string(val) != "value1" {
+		t.Errorf("expected value1 from the back tier, got %q", val)
+		return
+	}
+	entry, ok := front.Peek("key1")
+	if !ok || string(entry.Val) != "value1" {
+		t.Errorf("expected key1 to be promoted to the front tier")
+		return
+	}
+	if entry.ETag != `"v1"` || entry.TTL != time.Minute {
+		t.Errorf("expected promotion to keep metadata, got %+v", entry)
+		return
+	}
+
+	tiered.Add("key2", []byte("value2"))
+	if _, ok := back.Get("key2"); !ok {
+		t.Errorf("expected writes to reach the back tier")
+		return
+	}
+
+	if keys := rangeKeys(tiered); len(keys) != 2 {
+		t.Errorf("expected 2 keys across tiers, got %v", keys)
+		return
+	}
+}
+
+func TestDiskStore(t *testing.T) {
+	store, err := NewDiskStore(t.TempDir(), time.Minute)
+	if err != nil {
+		t.Fatalf("failed to create disk store: %v", err)
+	}
+
+	store.Add("key1", []byte("value1"))
+	store.AddEntry("old", Entry{Val: []byte("old"), CreatedAt: time.Now().Add(-time.Hour), TTL: time.Minute})
+
+	if val, ok := store.Get("key1"); !ok || string(val) != "value1" {
+		t.Errorf("expected value1, got %q", val)
+		return
+	}
+	if _, ok := store.Get("old"); ok {
+		t.Errorf("expected expired entry to miss")
+		return
+	}
+	if keys := rangeKeys(store); len(keys) != 1 || keys[0] != "key1" {
+		t.Errorf("expected only key1, got %v", keys)
+		return
+	}
+
+	if !store.Delete("key1") {
+		t.Errorf("expected delete to remove key1")
+		return
+	}
+	if _, ok := store.Get("key1"); ok {
+		t.Errorf("expected key1 to be gone")
+		return
+	}
+}
+
+func TestTypedOverNamespacedStoreMemoizes(t *testing.T) {
+	cache := NewCache(5 * time.Second)
+	defer cache.Close()
+	ns := Namespaced(cache, "ns")
+
+	decodes := 0
+	typed := NewTypedWithDecoder(ns, func(data []byte) (string, error) {
+		decodes++
+		return string(data), nil
+	})
+
+	ns.Add("key1", []byte("value1"))
+	for i := 0; i < 3; i++ {
+		if val, ok := typed.Get("key1"); !ok || val != "value1" {
+			t.Errorf("expected decoded value1, got %q", val)
+			return
+		}
+	}
+	if decodes != 1 {
+		t.Errorf("expected a single decode, got %d", decodes)
+		return
+	}
+}
+
+func TestTypedOverPlainStoreDecodes(t *testing.T) {
+	store, err := NewDiskStore(t.TempDir(), time.Minute)
+	if err != nil {
+		t.Fatalf("failed to create disk store: %v", err)
+	}
+	typed := NewTyped[typedTestValue](store)
+
+	store.Add("key1", []byte(`{"name":"disk","count":3}`))
+	if val, ok := typed.Get("key1"); !ok || val.Name != "disk" || val.Count != 3 {
+		t.Errorf("expected decoded disk value, got %+v", val)
+		return
+	}
+}
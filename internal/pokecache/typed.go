@@ -0,0 +1,125 @@
+package pokecache
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// Typed is a view of a Store that hands out decoded values instead of bytes.
+// The bytes stay the source of truth: for a Cache (directly or through a
+// Namespaced view) the first Get of an entry decodes it and memoizes the
+// result on the entry, so later hits skip decoding until the entry is
+// replaced, evicted or expires. Memoized values count against the byte
+// budget of the cache at an estimate of the memory they hold. Other stores
+// decode on every Get. Callers share the memoized value and must not modify
+// anything it references.
+type Typed[T any] struct {
+	store  Store
+	decode func([]byte) (T, error)
+}
+
+// NewTyped returns a Typed view of s that decodes entries as JSON.
+func NewTyped[T any](s Store) *Typed[T] {
+	return NewTypedWithDecoder(s, func(data []byte) (T, error) {
+		var v T
+		err := json.Unmarshal(data, &v)
+		return v, err
+	})
+}
+
+// NewTypedWithDecoder returns a Typed view of s using decode.
+func NewTypedWithDecoder[T any](s Store, decode func([]byte) (T, error)) *Typed[T] {
+	return &Typed[T]{store: s, decode: decode}
+}
+
+// Get returns the decoded value stored under key. It counts as a hit or miss
+// like Cache.Get; an entry that fails to decode is reported as a miss.
+func (t *Typed[T]) Get(key string) (T, bool) {
+	var zero T
+
+	ds, ok := t.store.(decodedStore)
+	if !ok {
+		data, ok := t.store.Get(key)
+		if !ok {
+			return zero, false
+		}
+		v, err := t.decode(data)
+		return v, err == nil
+	}
+
+	entry, decoded, ok := ds.getEntry(key)
+	if !ok {
+		return zero, false
+	}
+	if v, ok := decoded.(T); ok {
+		return v, true
+	}
+
+	data, err := entry.value()
+	if err != nil {
+		return zero, false
+	}
+	v, err := t.decode(data)
+	if err != nil {
+		return zero, false
+	}
+	ds.setDecoded(key, entry, v)
+	return v, true
+}
+
+// decodedSize estimates the memory held by v: the value itself plus the
+// strings, slices, maps and pointers it references. Decoded JSON has no
+// cycles, so shared references are counted once per path.
+func decodedSize(v any) int {
+	if v == nil {
+		return 0
+	}
+	rv := reflect.ValueOf(v)
+	return int(rv.Type().Size()) + referencedSize(rv)
+}
+
+// referencedSize is the memory v references outside of itself.
+func referencedSize(v reflect.Value) int {
+	switch v.Kind() {
+	case reflect.String:
+		return v.Len()
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return 0
+		}
+		elem := v.Elem()
+		return int(elem.Type().Size()) + referencedSize(elem)
+	case reflect.Slice:
+		if v.IsNil() {
+			return 0
+		}
+		n := v.Cap() * int(v.Type().Elem().Size())
+		for i := 0; i < v.Len(); i++ {
+			n += referencedSize(v.Index(i))
+		}
+		return n
+	case reflect.Array:
+		n := 0
+		for i := 0; i < v.Len(); i++ {
+			n += referencedSize(v.Index(i))
+		}
+		return n
+	case reflect.Struct:
+		n := 0
+		for i := 0; i < v.NumField(); i++ {
+			n += referencedSize(v.Field(i))
+		}
+		return n
+	case reflect.Map:
+		if v.IsNil() {
+			return 0
+		}
+		n := v.Len() * int(v.Type().Key().Size()+v.Type().Elem().Size())
+		iter := v.MapRange()
+		for iter.Next() {
+			n += referencedSize(iter.Key()) + referencedSize(iter.Value())
+		}
+		return n
+	}
+	return 0
+}
@@ -0,0 +1,158 @@
+package pokecache
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type typedTestValue struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+func TestTypedDecodesAndMemoizes(t *testing.T) {
+	cache := NewCache(5 * time.Second)
+	defer cache.Close()
+
+	decodes := 0
+	typed := NewTypedWithDecoder(cache, func(data []byte) (string, error) {
+		decodes++
+		return string(data), nil
+	})
+
+	cache.Add("key1", []byte("value1"))
+
+	for i := 0; i < 3; i++ {
+		val, ok := typed.Get("key1")
+		if !ok || val != "value1" {
+			t.Errorf("expected decoded value1, got %q", val)
+			return
+		}
+	}
+	if decodes != 1 {
+		t.Errorf("expected a single decode, got %d", decodes)
+		return
+	}
+}
+
+func TestTypedRedecodesReplacedEntry(t *testing.T) {
+	cache := NewCache(5 * time.Second)
+	defer cache.Close()
+	typed := NewTyped[typedTestValue](cache)
+
+	cache.Add("key1", []byte(`{"name":"first","count":1}`))
+	if val, ok := typed.Get("key1"); !ok || val.Name != "first" {
+		t.Errorf("expected first value, got %+v", val)
+		return
+	}
+
+	cache.Add("key1", []byte(`{"name":"second","count":2}`))
+	if val, ok := typed.Get("key1"); !ok || val.Name != "second" || val.Count != 2 {
+		t.Errorf("expected replaced value to be decoded, got %+v", val)
+		return
+	}
+}
+
+func TestTypedMissAndDecodeError(t *testing.T) {
+	cache := NewCache(5 * time.Second)
+	defer cache.Close()
+	typed := NewTyped[typedTestValue](cache)
+
+	if _, ok := typed.Get("missing"); ok {
+		t.Errorf("expected miss for missing key")
+		return
+	}
+
+	cache.Add("broken", []byte("not json"))
+	if _, ok := typed.Get("broken"); ok {
+		t.Errorf("expected miss for undecodable entry")
+		return
+	}
+
+	if stats := cache.Stats(); stats.Misses != 1 || stats.Hits != 1 {
+		t.Errorf("expected typed lookups to count like Get, got %+v", stats)
+		return
+	}
+}
+
+func TestTypedDifferentTypesSameKey(t *testing.T) {
+	cache := NewCache(5 * time.Second)
+	defer cache.Close()
+
+	cache.Add("key1", []byte(`{"name":"pikachu","count":25}`))
+
+	if val, ok := NewTyped[typedTestValue](cache).Get("key1"); !ok || val.Count != 25 {
+		t.Errorf("expected struct value, got %+v", val)
+		return
+	}
+	if val, ok := NewTyped[map[string]any](cache).Get("key1"); !ok || val["name"] != "pikachu" {
+		t.Errorf("expected map value, got %+v", val)
+		return
+	}
+}
+
+func TestTypedChargesDecodedValuesToBudget(t *testing.T) {
+	name := strings.Repeat("a", 100)
+	val := []byte(`{"name":"` + name + `","count":1}`)
+	stored := len("key1") + len(val)
+	decoded := decodedSize(typedTestValue{Name: name, Count: 1})
+
+	// Room for both entries but only one decoded value
+	cache := NewCache(5*time.Second, WithMaxBytes(2*stored+decoded+decoded/2))
+	defer cache.Close()
+	typed := NewTyped[typedTestValue](cache)
+
+	cache.Add("key1", val)
+	cache.Add("key2", val)
+
+	if _, ok := typed.Get("key1"); !ok {
+		t.Errorf("expected key1 to decode")
+		return
+	}
+	if stats := cache.Stats(); stats.DecodedBytes != decoded || stats.Bytes != 2*stored {
+		t.Errorf("expected %d decoded bytes on top of %d stored, got %+v", decoded, 2*stored, stats)
+		return
+	}
+
+	if _, ok := typed.Get("key2"); !ok {
+		t.Errorf("expected key2 to decode")
+		return
+	}
+	if _, ok := cache.Get("key1"); ok {
+		t.Errorf("expected the second decoded value to evict the least recently used entry")
+		return
+	}
+	if stats := cache.Stats(); stats.Entries != 1 || stats.DecodedBytes != decoded || stats.Evictions != 1 {
+		t.Errorf("expected key2 and its decoded value to be left, got %+v", stats)
+		return
+	}
+}
+
+func TestTypedSkipsMemoLargerThanBudget(t *testing.T) {
+	// The entry takes 10 bytes, its decoded string a header and 6 more
+	cache := NewCache(5*time.Second, WithMaxBytes(20))
+	defer cache.Close()
+
+	decodes := 0
+	typed := NewTypedWithDecoder(cache, func(data []byte) (string, error) {
+		decodes++
+		return string(data), nil
+	})
+
+	cache.Add("key1", []byte("value1"))
+	for i := 0; i < 2; i++ {
+		if val, ok := typed.Get("key1"); !ok || val != "value1" {
+			t.Errorf("expected decoded value1, got %q", val)
+			return
+		}
+	}
+	if decodes != 2 {
+		t.Errorf("expected a value over budget to be decoded every time, got %d decodes", decodes)
+		return
+	}
+	if stats := cache.Stats(); stats.Entries != 1 || stats.DecodedBytes != 0 {
+		t.Errorf("expected the entry to stay cached without its decoded value, got %+v", stats)
+		return
+	}
+}
@@ -1,9 +1,12 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"math/rand"
 	"os"
+	"os/signal"
 	"strings"
 	"time"
 
@@ -21,16 +24,27 @@ func cleanInput(text string) []string {
 type cliCommands struct {
 	name        string
 	description string
-	callback    func(c *pokeapi.Config, args ...string) error
+	callback    func(ctx context.Context, c *pokeapi.Config, args ...string) error
+	// keepCase passes arguments as typed instead of lowercased, e.g. for
+	// file paths.
+	keepCase bool
 }
 
 const INTRO_STRING string = "Pokedex > "
 const USER_INPUT_PREFIX string = "Your command was: "
 const WELCOME_STRING string = "Welcome to the Pokedex!"
+const CACHE_MAX_BYTES int = 64 << 20
+const CACHE_STALE_WINDOW time.Duration = 30 * 24 * time.Hour
+const CACHE_COMPRESS_THRESHOLD int = 4 << 10
+const BASE_URL_ENV string = "POKEDEX_BASE_URL"
 
 var supportedCommands map[string]cliCommands
 var userConfig pokeapi.Config
 
+// appCache is the cache behind userConfig.Client, kept for the cache command
+// and for closing on exit.
+var appCache *pokecache.Cache
+
 func init() {
 
 	supportedCommands = map[string]cliCommands{
@@ -69,25 +83,102 @@ func init() {
 			description: "Inspect a caught Pokemon. Usage: inspect <pokemon-name>",
 			callback:    commandInspect,
 		},
+		"moves": {
+			name:        "moves",
+			description: "List the moves a Pokemon learns, sorted by level, with power, accuracy, PP, type and effect. " + MOVES_USAGE,
+			callback:    commandMoves,
+		},
+		"ability": {
+			name:        "ability",
+			description: "Describe an ability and list every Pokemon that can have it. " + ABILITY_USAGE,
+			callback:    commandAbility,
+		},
+		"weakness": {
+			name:        "weakness",
+			description: "Show which attacking types are super effective or resisted against a Pokemon. " + WEAKNESS_USAGE,
+			callback:    commandWeakness,
+		},
+		"matchup": {
+			name:        "matchup",
+			description: "Show how effective an attacking type is against a Pokemon. " + MATCHUP_USAGE,
+			callback:    commandMatchup,
+		},
+		"evolution": {
+			name:        "evolution",
+			description: "Show how a Pokemon evolves, with the level, item, trade or friendship each step needs. " + EVOLUTION_USAGE,
+			callback:    commandEvolution,
+		},
 		"pokedx": {
 			name:        "pokedx",
 			description: "List all caught Pokemon in your Pokedex",
 			callback:    commandPokedx,
 		},
+		"cache": {
+			name:        "cache",
+			description: "Inspect or manage the response cache. " + CACHE_USAGE,
+			callback:    commandCache,
+			keepCase:    true,
+		},
+		"warm": {
+			name:        "warm",
+			description: "Prefetch every location area and the Pokemon found in them for offline use. " + WARM_USAGE,
+			callback:    commandWarm,
+		},
+		"debug": {
+			name:        "debug",
+			description: "Verbose mode: show cache events, requests and rate limit waits as they happen. " + DEBUG_USAGE,
+			callback:    commandDebug,
+		},
 	}
 
 	// rand.Seed(time.Now().UnixNano())
 
 	interval := time.Duration(time.Second * 10)
-	cache := pokecache.NewCache(interval)
+	appCache = newCache(interval)
 	userConfig = pokeapi.Config{
 		Next:          "",
 		Previous:      "",
-		Cache:         cache,
+		Client:        newClient(appCache),
 		CaughtPokemon: make(map[string]pokeapi.Pokemon),
 	}
 }
 
+// newCache prefers the persistent on-disk cache and falls back to a purely
+// in-memory one when the cache directory cannot be used. The interval is the
+// reap period and the TTL of keys the PokéAPI policy does not cover.
+func newCache(interval time.Duration) *pokecache.Cache {
+	opts := []pokecache.Option{
+		pokecache.WithMaxBytes(CACHE_MAX_BYTES),
+		pokecache.WithTTLPolicy(pokeapi.CacheTTL),
+		pokecache.WithStaleWindow(CACHE_STALE_WINDOW),
+		pokecache.WithCompression(CACHE_COMPRESS_THRESHOLD),
+	}
+
+	dir, err := pokecache.DefaultDir()
+	if err == nil {
+		cache, err := pokecache.NewPersistentCache(interval, dir, opts...)
+		if err == nil {
+			return cache
+		}
+	}
+	return pokecache.NewCache(interval, opts...)
+}
+
+// newClient returns a client for the API at $POKEDEX_BASE_URL, e.g. a local
+// mirror, or the public PokéAPI. Each base URL gets its own cache namespace.
+func newClient(cache *pokecache.Cache) *pokeapi.Client {
+	baseURL := pokeapi.BASE_URL
+	if env := os.Getenv(BASE_URL_ENV); env != "" {
+		baseURL = env
+	}
+	return pokeapi.NewClient(
+		pokecache.Namespaced(cache, pokeapi.CacheNamespace(baseURL)),
+		pokeapi.WithBaseURL(baseURL),
+		pokeapi.WithWaitObserver(printRateLimitWait),
+		pokeapi.WithMiddleware(logFetches),
+	)
+}
+
 func printLocations(locations []pokeapi.Result) {
 	for i := 0; i < len(locations); i++ {
 		fmt.Printf("%d => %s\n", i+1, locations[i].Name)
@@ -96,8 +187,8 @@ func printLocations(locations []pokeapi.Result) {
 
 }
 
-func commandMap(c *pokeapi.Config, args ...string) error {
-	allLocations, err := pokeapi.GetNextLocations(c)
+func commandMap(ctx context.Context, c *pokeapi.Config, args ...string) error {
+	allLocations, err := c.GetNextLocationsWithContext(ctx)
 	// fmt.Println(locationArea)
 	if err != nil {
 		return fmt.Errorf("Error fetching locations: %w", err)
@@ -107,8 +198,8 @@ func commandMap(c *pokeapi.Config, args ...string) error {
 	return nil
 }
 
-func commandMapBack(c *pokeapi.Config, args ...string) error {
-	allLocations, err := pokeapi.GetPrevLocations(c)
+func commandMapBack(ctx context.Context, c *pokeapi.Config, args ...string) error {
+	allLocations, err := c.GetPrevLocationsWithContext(ctx)
 	// fmt.Println(locationArea)
 	if err != nil {
 		return fmt.Errorf("Error fetching locations: %w", err)
@@ -118,13 +209,14 @@ func commandMapBack(c *pokeapi.Config, args ...string) error {
 	return nil
 }
 
-func commandExit(c *pokeapi.Config, args ...string) error {
+func commandExit(ctx context.Context, c *pokeapi.Config, args ...string) error {
 	fmt.Println("Closing the Pokedex... Goodbye!")
+	appCache.Close()
 	os.Exit(0)
 	return nil
 }
 
-func commandHelp(c *pokeapi.Config, args ...string) error {
+func commandHelp(ctx context.Context, c *pokeapi.Config, args ...string) error {
 	fmt.Println("Usage:")
 	for cmdName, cmd := range supportedCommands {
 		fmt.Printf("- %s: %s\n", cmdName, cmd.description)
@@ -133,7 +225,7 @@ func commandHelp(c *pokeapi.Config, args ...string) error {
 	return nil
 }
 
-func commandExplore(c *pokeapi.Config, args ...string) error {
+func commandExplore(ctx context.Context, c *pokeapi.Config, args ...string) error {
 	if len(args) == 0 {
 		return fmt.Errorf("you must provide a location name. Usage: explore <location-name>")
 	}
@@ -141,7 +233,10 @@ func commandExplore(c *pokeapi.Config, args ...string) error {
 	locationName := args[0]
 	fmt.Printf("Exploring %s...\n", locationName)
 
-	locationInfo, err := pokeapi.GetLocationInformation(c, locationName)
+	locationInfo, err := c.Client.GetLocationInformationWithContext(ctx, locationName)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		return fmt.Errorf("no location area named %s", locationName)
+	}
 	if err != nil {
 		return fmt.Errorf("Error exploring location: %w", err)
 	}
@@ -159,7 +254,7 @@ func commandExplore(c *pokeapi.Config, args ...string) error {
 	return nil
 }
 
-func commandCatch(c *pokeapi.Config, args ...string) error {
+func commandCatch(ctx context.Context, c *pokeapi.Config, args ...string) error {
 	if len(args) == 0 {
 		return fmt.Errorf("you must provide a Pokemon name. Usage: catch <pokemon-name>")
 	}
@@ -172,7 +267,10 @@ func commandCatch(c *pokeapi.Config, args ...string) error {
 		return nil
 	}
 
-	pokemon, err := pokeapi.GetPokemon(c, pokemonName)
+	pokemon, err := c.Client.GetPokemonWithContext(ctx, pokemonName)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		return fmt.Errorf("no Pokémon named %s", pokemonName)
+	}
 	if err != nil {
 		return fmt.Errorf("Error getting Pokemon data: %w", err)
 	}
@@ -197,7 +295,7 @@ func commandCatch(c *pokeapi.Config, args ...string) error {
 	return nil
 }
 
-func commandInspect(c *pokeapi.Config, args ...string) error {
+func commandInspect(ctx context.Context, c *pokeapi.Config, args ...string) error {
 	if len(args) == 0 {
 		return fmt.Errorf("you must provide a Pokemon name. Usage: inspect <pokemon-name>")
 	}
@@ -220,11 +318,19 @@ func commandInspect(c *pokeapi.Config, args ...string) error {
 	for _, typeInfo := range pokemon.Types {
 		fmt.Printf("  - %s\n", typeInfo.Type.Name)
 	}
+	fmt.Printf("Abilities:\n")
+	for _, abilityInfo := range pokemon.Abilities {
+		if abilityInfo.IsHidden {
+			fmt.Printf("  - %s (hidden)\n", abilityInfo.Ability.Name)
+		} else {
+			fmt.Printf("  - %s\n", abilityInfo.Ability.Name)
+		}
+	}
 
 	return nil
 }
 
-func commandPokedx(c *pokeapi.Config, args ...string) error {
+func commandPokedx(ctx context.Context, c *pokeapi.Config, args ...string) error {
 	fmt.Println("Your Pokedx:")
 
 	if len(c.CaughtPokemon) == 0 {
@@ -262,6 +368,7 @@ func main() {
 		return
 	}
 	defer rl.Close()
+	defer appCache.Close()
 
 	fmt.Println(WELCOME_STRING)
 	fmt.Println("Use UP/DOWN arrows to navigate command history, TAB for autocomplete")
@@ -276,21 +383,58 @@ func main() {
 			break
 		}
 
-		userInput := cleanInput(line)
-		if len(userInput) == 0 {
-			continue
-		}
+		runCommand(line)
+	}
+}
 
-		commandToExpect := userInput[0]
-		if command, ok := supportedCommands[commandToExpect]; !ok {
-			fmt.Println("Command not found. Type 'help' to see available commands")
-		} else {
-			args := userInput[1:]
-			if err := supportedCommands[command.name].callback(&userConfig, args...); err != nil {
-				fmt.Println(err)
-			}
-		}
+// runCommand looks up the command named by the first word of line and runs
+// it with the remaining words as arguments.
+func runCommand(line string) {
+	userInput := cleanInput(line)
+	if len(userInput) == 0 {
+		return
+	}
+
+	commandToExpect := userInput[0]
+	command, ok := supportedCommands[commandToExpect]
+	if !ok {
+		fmt.Println("Command not found. Type 'help' to see available commands")
+		return
+	}
+
+	args := userInput[1:]
+	if command.keepCase {
+		args = strings.Fields(line)[1:]
 	}
+
+	// Ctrl-C while a command runs cancels it instead of killing the
+	// Pokedex. At the prompt readline reports it as ErrInterrupt instead.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	err := command.callback(ctx, &userConfig, args...)
+	if errors.Is(err, context.Canceled) {
+		fmt.Println("\nCancelled.")
+		return
+	}
+	if err != nil {
+		fmt.Println(describeError(err))
+	}
+}
+
+// describeError explains API failures that are not the player's fault in
+// plain words and returns other errors' messages as they are.
+func describeError(err error) string {
+	var decodeErr *pokeapi.DecodeError
+	switch {
+	case errors.Is(err, pokeapi.ErrRateLimited):
+		return "PokéAPI is limiting how fast we can ask for data. Try again in a moment."
+	case errors.Is(err, pokeapi.ErrServer):
+		return "PokéAPI is having trouble right now. Try again later."
+	case errors.As(err, &decodeErr):
+		return "PokéAPI sent a response the Pokedex could not read."
+	}
+	return err.Error()
 }
 
 func fallbackInputLoop() {
@@ -304,20 +448,7 @@ func fallbackInputLoop() {
 			continue
 		}
 
-		userInput := cleanInput(input)
-		if len(userInput) == 0 {
-			continue
-		}
-
-		commandToExpect := userInput[0]
-		if command, ok := supportedCommands[commandToExpect]; !ok {
-			fmt.Println("Command not found. Type 'help' to see available commands")
-		} else {
-			args := userInput[1:]
-			if err := supportedCommands[command.name].callback(&userConfig, args...); err != nil {
-				fmt.Println(err)
-			}
-		}
+		runCommand(input)
 	}
 }
 
@@ -361,5 +492,71 @@ var completer = readline.NewPrefixCompleter(
 		readline.PcItem("magikarp"),
 		readline.PcItem("gyarados"),
 	),
+	readline.PcItem("moves",
+		readline.PcItem("pikachu"),
+		readline.PcItem("charizard"),
+		readline.PcItem("blastoise"),
+		readline.PcItem("venusaur"),
+		readline.PcItem("mewtwo"),
+		readline.PcItem("gyarados"),
+	),
+	readline.PcItem("ability",
+		readline.PcItem("static"),
+		readline.PcItem("overgrow"),
+		readline.PcItem("blaze"),
+		readline.PcItem("torrent"),
+		readline.PcItem("levitate"),
+		readline.PcItem("intimidate"),
+	),
+	readline.PcItem("weakness",
+		readline.PcItem("pikachu"),
+		readline.PcItem("charizard"),
+		readline.PcItem("blastoise"),
+		readline.PcItem("venusaur"),
+		readline.PcItem("gyarados"),
+		readline.PcItem("mewtwo"),
+	),
+	readline.PcItem("matchup",
+		readline.PcItem("normal"),
+		readline.PcItem("fire"),
+		readline.PcItem("water"),
+		readline.PcItem("electric"),
+		readline.PcItem("grass"),
+		readline.PcItem("ice"),
+		readline.PcItem("fighting"),
+		readline.PcItem("poison"),
+		readline.PcItem("ground"),
+		readline.PcItem("flying"),
+		readline.PcItem("psychic"),
+		readline.PcItem("bug"),
+		readline.PcItem("rock"),
+		readline.PcItem("ghost"),
+		readline.PcItem("dragon"),
+		readline.PcItem("dark"),
+		readline.PcItem("steel"),
+		readline.PcItem("fairy"),
+	),
+	readline.PcItem("evolution",
+		readline.PcItem("pikachu"),
+		readline.PcItem("charmander"),
+		readline.PcItem("bulbasaur"),
+		readline.PcItem("squirtle"),
+		readline.PcItem("eevee"),
+		readline.PcItem("magikarp"),
+		readline.PcItem("onix"),
+	),
 	readline.PcItem("pokedx"),
+	readline.PcItem("warm"),
+	readline.PcItem("debug",
+		readline.PcItem("on"),
+		readline.PcItem("off"),
+	),
+	readline.PcItem("cache",
+		readline.PcItem("stats"),
+		readline.PcItem("keys"),
+		readline.PcItem("clear"),
+		readline.PcItem("evict"),
+		readline.PcItem("export"),
+		readline.PcItem("import"),
+	),
 )